@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"movieexample.com/rating/pkg/model"
+)
+
+// Distributions of generated rating events.
+const (
+	distributionUniform = "uniform"
+	distributionZipf    = "zipf"
+	distributionNormal  = "normal"
+)
+
+// generatorConfig defines the synthetic load of the generate mode.
+type generatorConfig struct {
+	// Rate is the number of events per second.
+	Rate       float64
+	Count      int
+	Duration   time.Duration
+	Records    int
+	Users      int
+	RecordType model.RecordType
+	Scale      model.Scale
+	// RecordDistribution picks the rated records: uniform or zipf, where a few records get most ratings.
+	RecordDistribution string
+	// ValueDistribution picks the rating values: uniform or normal around the middle of the scale.
+	ValueDistribution string
+	Seed              int64
+}
+
+func (c generatorConfig) validate() error {
+	if c.Rate <= 0 {
+		return fmt.Errorf("rate must be positive")
+	}
+	if c.Records <= 0 || c.Users <= 0 {
+		return fmt.Errorf("records and users must be positive")
+	}
+	if c.RecordDistribution != distributionUniform && c.RecordDistribution != distributionZipf {
+		return fmt.Errorf("unknown record distribution %q", c.RecordDistribution)
+	}
+	if c.ValueDistribution != distributionUniform && c.ValueDistribution != distributionNormal {
+		return fmt.Errorf("unknown value distribution %q", c.ValueDistribution)
+	}
+	return nil
+}
+
+// generateEvents returns a source of synthetic put events paced at the configured rate. It ends
+// once the count or the duration is reached, whichever is set, or when the context is cancelled.
+func generateEvents(ctx context.Context, cfg generatorConfig) eventSource {
+	rnd := rand.New(rand.NewSource(cfg.Seed))
+	var zipf *rand.Zipf
+	if cfg.RecordDistribution == distributionZipf && cfg.Records > 1 {
+		zipf = rand.NewZipf(rnd, 1.1, 1, uint64(cfg.Records-1))
+	}
+	step := cfg.Scale.Step
+	if step <= 0 {
+		step = 1
+	}
+	values := int((cfg.Scale.Max-cfg.Scale.Min)/step) + 1
+	runID := strconv.FormatInt(time.Now().UnixNano(), 36)
+	start := time.Now()
+	n := 0
+	return func() (*model.RatingEvent, error) {
+		if cfg.Count > 0 && n >= cfg.Count || cfg.Duration > 0 && time.Since(start) >= cfg.Duration || ctx.Err() != nil {
+			return nil, io.EOF
+		}
+		next := start.Add(time.Duration(float64(n) / cfg.Rate * float64(time.Second)))
+		select {
+		case <-ctx.Done():
+			return nil, io.EOF
+		case <-time.After(time.Until(next)):
+		}
+		n++
+
+		record := rnd.Intn(cfg.Records)
+		if zipf != nil {
+			record = int(zipf.Uint64())
+		}
+		var i int
+		if cfg.ValueDistribution == distributionNormal {
+			mid := float64(values-1) / 2
+			i = int(math.Round(rnd.NormFloat64()*float64(values)/4 + mid))
+			i = max(0, min(values-1, i))
+		} else {
+			i = rnd.Intn(values)
+		}
+		return &model.RatingEvent{
+			ID:         runID + "-" + strconv.Itoa(n),
+			UserID:     model.UserID(strconv.Itoa(rnd.Intn(cfg.Users) + 1)),
+			RecordID:   model.RecordID(strconv.Itoa(record + 1)),
+			RecordType: cfg.RecordType,
+			Value:      cfg.Scale.Min + model.RatingValue(i)*step,
+			EventType:  model.RatingEventTypePut,
+			Timestamp:  time.Now().UTC(),
+			ProviderID: "ratingingester",
+		}, nil
+	}
+}
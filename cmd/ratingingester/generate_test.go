@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"movieexample.com/rating/pkg/model"
+)
+
+func testGeneratorConfig() generatorConfig {
+	return generatorConfig{
+		Rate:               1e6,
+		Count:              2000,
+		Records:            20,
+		Users:              50,
+		RecordType:         model.RecordTypeEpisode,
+		Scale:              model.Scale{Min: 1, Max: 10, Step: 1},
+		RecordDistribution: distributionUniform,
+		ValueDistribution:  distributionUniform,
+		Seed:               1,
+	}
+}
+
+func collectEvents(t *testing.T, next eventSource) []model.RatingEvent {
+	var res []model.RatingEvent
+	for {
+		e, err := next()
+		if errors.Is(err, io.EOF) {
+			return res
+		}
+		require.NoError(t, err)
+		res = append(res, *e)
+	}
+}
+
+func TestGenerateEventsDistributions(t *testing.T) {
+	tests := []struct {
+		name   string
+		record string
+		value  string
+		check  func(t *testing.T, records map[model.RecordID]int, values map[model.RatingValue]int)
+	}{
+		{
+			name: "uniform", record: distributionUniform, value: distributionUniform,
+			check: func(t *testing.T, records map[model.RecordID]int, values map[model.RatingValue]int) {
+				assert.Len(t, records, 20)
+				assert.Len(t, values, 10)
+				for v, n := range values {
+					assert.InDelta(t, 200, n, 80, "value %d", v)
+				}
+			},
+		},
+		{
+			name: "zipf", record: distributionZipf, value: distributionUniform,
+			check: func(t *testing.T, records map[model.RecordID]int, values map[model.RatingValue]int) {
+				assert.Greater(t, records["1"], records["2"])
+				assert.Greater(t, records["2"], records["10"])
+				assert.Greater(t, records["1"], 2000/4, "the first record gets most ratings")
+			},
+		},
+		{
+			name: "normal", record: distributionUniform, value: distributionNormal,
+			check: func(t *testing.T, records map[model.RecordID]int, values map[model.RatingValue]int) {
+				assert.Greater(t, values[5]+values[6], 2*(values[1]+values[10]), "the middle of the scale is the most common")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testGeneratorConfig()
+			cfg.RecordDistribution, cfg.ValueDistribution = tt.record, tt.value
+			require.NoError(t, cfg.validate())
+			events := collectEvents(t, generateEvents(context.Background(), cfg))
+			require.Len(t, events, cfg.Count)
+			records := map[model.RecordID]int{}
+			values := map[model.RatingValue]int{}
+			ids := map[string]bool{}
+			for _, e := range events {
+				assert.NoError(t, validateEvent(&e, model.ScaleRegistry{cfg.RecordType: cfg.Scale}))
+				records[e.RecordID]++
+				values[e.Value]++
+				ids[e.ID] = true
+			}
+			assert.Len(t, ids, cfg.Count, "event ids are unique")
+			tt.check(t, records, values)
+		})
+	}
+}
+
+func TestGenerateEventsLimits(t *testing.T) {
+	cfg := testGeneratorConfig()
+	cfg.Count, cfg.Rate, cfg.Duration = 0, 200, 100*time.Millisecond
+	start := time.Now()
+	events := collectEvents(t, generateEvents(context.Background(), cfg))
+	assert.GreaterOrEqual(t, time.Since(start), cfg.Duration)
+	assert.InDelta(t, 20, len(events), 10, "the rate paces the events of the duration")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cfg.Duration = 0
+	assert.Empty(t, collectEvents(t, generateEvents(ctx, cfg)), "a cancelled generator ends")
+}
+
+func TestGeneratorConfigValidate(t *testing.T) {
+	for name, change := range map[string]func(*generatorConfig){
+		"rate":                func(c *generatorConfig) { c.Rate = 0 },
+		"records":             func(c *generatorConfig) { c.Records = 0 },
+		"users":               func(c *generatorConfig) { c.Users = -1 },
+		"record distribution": func(c *generatorConfig) { c.RecordDistribution = "normal" },
+		"value distribution":  func(c *generatorConfig) { c.ValueDistribution = "zipf" },
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := testGeneratorConfig()
+			change(&cfg)
+			assert.Error(t, cfg.validate())
+		})
+	}
+}
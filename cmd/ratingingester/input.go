@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"movieexample.com/rating/pkg/model"
+)
+
+// Input formats of rating event files.
+const (
+	formatJSON   = "json"
+	formatNDJSON = "ndjson"
+	formatCSV    = "csv"
+)
+
+// eventSource returns the next rating event or io.EOF once there are no more events.
+type eventSource func() (*model.RatingEvent, error)
+
+// formatOf returns the input format of a file, guessing it from the file extension if the format is empty.
+func formatOf(format string, filename string) (string, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(filename)) {
+		case ".ndjson", ".jsonl":
+			return formatNDJSON, nil
+		case ".csv":
+			return formatCSV, nil
+		default:
+			return formatJSON, nil
+		}
+	}
+	switch format {
+	case formatJSON, formatNDJSON, formatCSV:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown input format %q", format)
+	}
+}
+
+// readEvents returns a source of the rating events of an input in the given format.
+func readEvents(r io.Reader, format string) (eventSource, error) {
+	switch format {
+	case formatJSON:
+		return readJSONArray(r)
+	case formatNDJSON:
+		return readNDJSON(r), nil
+	case formatCSV:
+		return readCSV(r)
+	default:
+		return nil, fmt.Errorf("unknown input format %q", format)
+	}
+}
+
+// readJSONArray reads a JSON array of legacy JSON rating events.
+func readJSONArray(r io.Reader) (eventSource, error) {
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil {
+		return nil, err
+	} else if tok != json.Delim('[') {
+		return nil, fmt.Errorf("expected a JSON array of rating events")
+	}
+	n := 0
+	return func() (*model.RatingEvent, error) {
+		if !dec.More() {
+			return nil, io.EOF
+		}
+		n++
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("event %d: %w", n, err)
+		}
+		e, err := model.DecodeRatingEvent(raw, model.ContentTypeJSON)
+		if err != nil {
+			return nil, fmt.Errorf("event %d: %w", n, err)
+		}
+		return e, nil
+	}, nil
+}
+
+// readNDJSON reads legacy JSON rating events, one per line. Blank lines are skipped.
+func readNDJSON(r io.Reader) eventSource {
+	scanner := bufio.NewScanner(r)
+	line := 0
+	return func() (*model.RatingEvent, error) {
+		for scanner.Scan() {
+			line++
+			if len(strings.TrimSpace(scanner.Text())) == 0 {
+				continue
+			}
+			e, err := model.DecodeRatingEvent(scanner.Bytes(), model.ContentTypeJSON)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			return e, nil
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+}
+
+// readCSV reads rating events from CSV with a header row naming the event fields,
+// e.g. user_id,record_id,record_type,value,event_type. Timestamps are in RFC 3339.
+func readCSV(r io.Reader) (eventSource, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		columns[strings.ToLower(strings.ReplaceAll(strings.TrimSpace(h), "_", ""))] = i
+	}
+	line := 1
+	return func() (*model.RatingEvent, error) {
+		record, err := cr.Read()
+		if err != nil {
+			return nil, err
+		}
+		line++
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return record[i]
+			}
+			return ""
+		}
+		e := &model.RatingEvent{
+			ID:         field("id"),
+			UserID:     model.UserID(field("userid")),
+			RecordID:   model.RecordID(field("recordid")),
+			RecordType: model.RecordType(field("recordtype")),
+			EventType:  model.RatingEventType(field("eventtype")),
+			ProviderID: field("providerid"),
+		}
+		if v := field("value"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: value: %w", line, err)
+			}
+			e.Value = model.RatingValue(n)
+		}
+		if ts := field("timestamp"); ts != "" {
+			if e.Timestamp, err = time.Parse(time.RFC3339, ts); err != nil {
+				return nil, fmt.Errorf("line %d: timestamp: %w", line, err)
+			}
+		}
+		return e, nil
+	}, nil
+}
+
+// validateEvent checks a rating event against the rating scales.
+func validateEvent(e *model.RatingEvent, scales model.ScaleRegistry) error {
+	if e.UserID == "" || e.RecordID == "" {
+		return fmt.Errorf("empty user id or record id")
+	}
+	switch e.EventType {
+	case model.RatingEventTypePut:
+		return scales.Validate(e.RecordType, e.Value)
+	case model.RatingEventTypeDelete:
+		if _, ok := scales[e.RecordType]; !ok {
+			return fmt.Errorf("%w: %q", model.ErrUnknownRecordType, e.RecordType)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown event type %q", e.EventType)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"movieexample.com/rating/pkg/model"
+)
+
+func TestReadEvents(t *testing.T) {
+	want := []model.RatingEvent{
+		{UserID: "105", RecordID: "1", RecordType: model.RecordTypeMovie, Value: 4, EventType: model.RatingEventTypePut},
+		{UserID: "106", RecordID: "2", RecordType: model.RecordTypeMovie, EventType: model.RatingEventTypeDelete},
+	}
+	tests := []struct {
+		format string
+		input  string
+	}{
+		{
+			format: formatJSON,
+			input:  `[{"userId":"105","recordId":"1","recordType":1,"value":4,"eventType":"put"},{"user_id":"106","record_id":"2","record_type":"movie","event_type":"delete"}]`,
+		},
+		{
+			format: formatNDJSON,
+			input:  "{\"userId\":\"105\",\"recordId\":\"1\",\"recordType\":1,\"value\":4,\"eventType\":\"put\"}\n\n{\"user_id\":\"106\",\"record_id\":\"2\",\"record_type\":\"movie\",\"event_type\":\"delete\"}\n",
+		},
+		{
+			format: formatCSV,
+			input:  "user_id,record_id,record_type,value,event_type\n105,1,movie,4,put\n106,2,movie,,delete\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			next, err := readEvents(strings.NewReader(tt.input), tt.format)
+			require.NoError(t, err)
+			var got []model.RatingEvent
+			for {
+				e, err := next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				require.NoError(t, err)
+				got = append(got, *e)
+			}
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestValidateEvent(t *testing.T) {
+	scales := model.DefaultScales()
+	tests := []struct {
+		name  string
+		event model.RatingEvent
+		want  error
+	}{
+		{name: "put", event: model.RatingEvent{UserID: "1", RecordID: "1", RecordType: model.RecordTypeMovie, Value: 5, EventType: model.RatingEventTypePut}},
+		{name: "put above scale", event: model.RatingEvent{UserID: "1", RecordID: "1", RecordType: model.RecordTypeMovie, Value: 6, EventType: model.RatingEventTypePut}, want: model.ErrInvalidRating},
+		{name: "put on episode scale", event: model.RatingEvent{UserID: "1", RecordID: "1", RecordType: model.RecordTypeEpisode, Value: 10, EventType: model.RatingEventTypePut}},
+		{name: "put unknown type", event: model.RatingEvent{UserID: "1", RecordID: "1", RecordType: "book", Value: 1, EventType: model.RatingEventTypePut}, want: model.ErrUnknownRecordType},
+		{name: "delete", event: model.RatingEvent{UserID: "1", RecordID: "1", RecordType: model.RecordTypeMovie, EventType: model.RatingEventTypeDelete}},
+		{name: "delete unknown type", event: model.RatingEvent{UserID: "1", RecordID: "1", RecordType: "book", EventType: model.RatingEventTypeDelete}, want: model.ErrUnknownRecordType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, validateEvent(&tt.event, scales), tt.want)
+		})
+	}
+	assert.Error(t, validateEvent(&model.RatingEvent{RecordID: "1", RecordType: model.RecordTypeMovie, Value: 1, EventType: model.RatingEventTypePut}, scales), "empty user id")
+	assert.Error(t, validateEvent(&model.RatingEvent{UserID: "1", RecordID: "1", RecordType: model.RecordTypeMovie, Value: 1, EventType: "upsert"}, scales), "unknown event type")
+}
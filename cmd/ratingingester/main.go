@@ -1,73 +1,190 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/confluentinc/confluent-kafka-go/kafka"
 	"movieexample.com/rating/pkg/model"
 )
 
+// ratingingester publishes rating events to Kafka, either from a file in JSON array,
+// NDJSON or CSV format or as synthetic load.
 func main() {
-	fmt.Println("Creating a Kafka producer")
-	producer, err := kafka.NewProducer(&kafka.ConfigMap{
-		"bootstrap.servers": "localhost",
-	})
-	if err != nil {
-		panic(err)
+	broker := flag.String("broker", "localhost", "Kafka broker address")
+	topic := flag.String("topic", "ratings", "topic to publish the rating events to")
+	file := flag.String("file", "ratingsdata.json", "input file of rating events, - for stdin")
+	format := flag.String("format", "", "input format: json, ndjson or csv, guessed from the file extension if empty")
+	encoding := flag.String("encoding", "json", "encoding of the published rating events: json or protobuf")
+	dryRun := flag.Bool("dry-run", false, "validate the rating events against the rating scales without publishing them")
+	timeout := flag.Duration("flush-timeout", 10*time.Second, "how long to wait for outstanding deliveries before exiting")
+
+	generate := flag.Bool("generate", false, "publish synthetic rating events instead of reading a file")
+	var gen generatorConfig
+	flag.Float64Var(&gen.Rate, "rate", 100, "synthetic events per second")
+	flag.IntVar(&gen.Count, "count", 1000, "number of synthetic events, 0 for no limit")
+	flag.DurationVar(&gen.Duration, "duration", 0, "how long to generate synthetic events, 0 for no limit")
+	flag.IntVar(&gen.Records, "records", 100, "number of distinct synthetic records")
+	flag.IntVar(&gen.Users, "users", 1000, "number of distinct synthetic users")
+	recordType := flag.String("record-type", string(model.RecordTypeMovie), "record type of synthetic events")
+	flag.StringVar(&gen.RecordDistribution, "record-distribution", distributionUniform, "distribution of rated records: uniform or zipf")
+	flag.StringVar(&gen.ValueDistribution, "value-distribution", distributionUniform, "distribution of rating values: uniform or normal")
+	flag.Int64Var(&gen.Seed, "seed", time.Now().UnixNano(), "random seed of synthetic events")
+	flag.Parse()
+
+	if err := run(*broker, *topic, *file, *format, *encoding, *dryRun, *timeout, *generate, gen, model.RecordType(*recordType)); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
-	defer producer.Close()
+}
 
-	const fileName = "ratingsdata.json"
-	fmt.Println("Reading rating events from file " + fileName)
+func run(broker, topic, file, format, encoding string, dryRun bool, timeout time.Duration, generate bool, gen generatorConfig, recordType model.RecordType) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	ratingEvents, err := readRatingEvents(fileName)
+	contentType, err := contentTypeOf(encoding)
 	if err != nil {
-		panic(err)
+		return err
 	}
+	scales := model.DefaultScales()
 
-	const topic = "ratings"
-	if err := produceRatingEvents(producer, topic, ratingEvents); err != nil {
-		panic(err)
+	var next eventSource
+	if generate {
+		scale, ok := scales[recordType]
+		if !ok {
+			return fmt.Errorf("%w: %q", model.ErrUnknownRecordType, recordType)
+		}
+		gen.RecordType, gen.Scale = recordType, scale
+		if err := gen.validate(); err != nil {
+			return err
+		}
+		fmt.Printf("Generating rating events at %v per second\n", gen.Rate)
+		next = generateEvents(ctx, gen)
+	} else {
+		if format, err = formatOf(format, file); err != nil {
+			return err
+		}
+		r := os.Stdin
+		if file != "-" {
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+		fmt.Printf("Reading %s rating events from %s\n", format, file)
+		if next, err = readEvents(r, format); err != nil {
+			return err
+		}
 	}
-	const timeout = 10 * time.Second
-	fmt.Println("Waiting " + timeout.String() + " until allevents get produced")
-	flushedNum := producer.Flush(int(timeout.Milliseconds()))
-	fmt.Println("Flushed " + fmt.Sprint(flushedNum) + " events")
 
-}
+	if dryRun {
+		return validateEvents(next, scales)
+	}
 
-func readRatingEvents(filename string) ([]model.RatingEvent, error) {
-	f, err := os.Open(filename)
+	fmt.Println("Creating a Kafka producer")
+	producer, err := kafka.NewProducer(&kafka.ConfigMap{
+		"bootstrap.servers": broker,
+	})
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer f.Close()
-	var ratingEvents []model.RatingEvent
-	if err := json.NewDecoder(f).Decode(&ratingEvents); err != nil {
-		return nil, err
+	report := &deliveryReport{}
+	reported := make(chan struct{})
+	go report.collect(producer.Events(), reported)
+
+	produced, err := produceEvents(producer, topic, contentType, next)
+	fmt.Printf("Waiting %v until all %d events get delivered\n", timeout, produced)
+	if remaining := producer.Flush(int(timeout.Milliseconds())); remaining > 0 {
+		fmt.Printf("%d events were not delivered in time\n", remaining)
 	}
-	return ratingEvents, nil
+	producer.Close()
+	<-reported
+	fmt.Println(report)
+	return err
 }
 
-func produceRatingEvents(producer *kafka.Producer, topic string, ratingEvents []model.RatingEvent) error {
-	for _, ratingEvent := range ratingEvents {
-		encodedEvent, err := json.Marshal(ratingEvent)
+// queueFullWait defines how long the producer gets to deliver queued events once its queue is full.
+const queueFullWait = 100 * time.Millisecond
+
+// messageProducer defines the part of a Kafka producer used to publish rating events.
+type messageProducer interface {
+	Produce(msg *kafka.Message, deliveryChan chan kafka.Event) error
+	Flush(timeoutMs int) int
+}
+
+// produceEvents publishes all events of a source and returns how many were handed to the producer.
+// When the local queue of the producer is full it waits for queued events to be delivered and tries again.
+func produceEvents(producer messageProducer, topic string, contentType string, next eventSource) (int, error) {
+	produced := 0
+	for {
+		e, err := next()
+		if errors.Is(err, io.EOF) {
+			return produced, nil
+		} else if err != nil {
+			return produced, err
+		}
+		msg, err := ratingMessage(topic, contentType, e)
 		if err != nil {
-			return err
+			return produced, err
 		}
-		message := &kafka.Message{
-			TopicPartition: kafka.TopicPartition{
-				Topic:     &topic,
-				Partition: kafka.PartitionAny,
-			},
-			Value: encodedEvent,
+		if err := produce(producer, msg); err != nil {
+			return produced, err
 		}
-		if err := producer.Produce(message, nil); err != nil {
+		produced++
+	}
+}
+
+func produce(producer messageProducer, msg *kafka.Message) error {
+	for {
+		err := producer.Produce(msg, nil)
+		var kafkaErr kafka.Error
+		if err == nil || !errors.As(err, &kafkaErr) || kafkaErr.Code() != kafka.ErrQueueFull {
 			return err
 		}
+		producer.Flush(int(queueFullWait.Milliseconds()))
+	}
+}
+
+// validateEvents validates all events of a source and reports the invalid ones.
+func validateEvents(next eventSource, scales model.ScaleRegistry) error {
+	valid, invalid := 0, 0
+	for n := 1; ; n++ {
+		e, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+		if err := validateEvent(e, scales); err != nil {
+			invalid++
+			fmt.Printf("Event %d: %v\n", n, err)
+			continue
+		}
+		valid++
+	}
+	fmt.Printf("Dry run: %d valid, %d invalid events\n", valid, invalid)
+	if invalid > 0 {
+		return fmt.Errorf("%d invalid events", invalid)
 	}
 	return nil
 }
+
+func contentTypeOf(encoding string) (string, error) {
+	switch encoding {
+	case "json":
+		return model.ContentTypeJSON, nil
+	case "protobuf":
+		return model.ContentTypeProtobuf, nil
+	default:
+		return "", fmt.Errorf("unknown encoding %q", encoding)
+	}
+}
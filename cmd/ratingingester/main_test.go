@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"movieexample.com/rating/pkg/model"
+)
+
+func TestValidateEvents(t *testing.T) {
+	input := "user_id,record_id,record_type,value,event_type\n105,1,movie,4,put\n106,2,movie,7,put\n107,3,episode,7,put\n"
+	next, err := readEvents(strings.NewReader(input), formatCSV)
+	require.NoError(t, err)
+	assert.EqualError(t, validateEvents(next, model.DefaultScales()), "1 invalid events")
+
+	next, err = readEvents(strings.NewReader(input), formatCSV)
+	require.NoError(t, err)
+	scales := model.DefaultScales()
+	scales[model.RecordTypeMovie] = model.Scale{Min: 1, Max: 10, Step: 1}
+	assert.NoError(t, validateEvents(next, scales))
+}
+
+// fakeProducer fails a number of produce calls with a full queue and counts the produced messages and flushes.
+type fakeProducer struct {
+	queueFull int
+	err       error
+	produced  int
+	flushes   int
+}
+
+func (p *fakeProducer) Produce(msg *kafka.Message, deliveryChan chan kafka.Event) error {
+	if p.queueFull > 0 {
+		p.queueFull--
+		return kafka.NewError(kafka.ErrQueueFull, "Local: Queue full", false)
+	}
+	if p.err != nil {
+		return p.err
+	}
+	p.produced++
+	return nil
+}
+
+func (p *fakeProducer) Flush(timeoutMs int) int {
+	p.flushes++
+	return 0
+}
+
+func eventsOf(events ...model.RatingEvent) eventSource {
+	return func() (*model.RatingEvent, error) {
+		if len(events) == 0 {
+			return nil, io.EOF
+		}
+		e := events[0]
+		events = events[1:]
+		return &e, nil
+	}
+}
+
+func TestProduceEvents(t *testing.T) {
+	e := model.RatingEvent{UserID: "1", RecordID: "1", RecordType: model.RecordTypeMovie, Value: 4, EventType: model.RatingEventTypePut}
+
+	p := &fakeProducer{queueFull: 3}
+	produced, err := produceEvents(p, "ratings", model.ContentTypeJSON, eventsOf(e, e))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, produced, "a full queue is retried")
+	assert.Equal(t, 2, p.produced)
+	assert.Equal(t, 3, p.flushes)
+
+	p = &fakeProducer{err: errors.New("broker down")}
+	produced, err = produceEvents(p, "ratings", model.ContentTypeJSON, eventsOf(e))
+	assert.EqualError(t, err, "broker down")
+	assert.Zero(t, produced)
+	assert.Zero(t, p.flushes)
+}
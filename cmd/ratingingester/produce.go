@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"movieexample.com/rating/pkg/model"
+)
+
+// deliveryReport summarizes the delivery reports of the produced rating events.
+type deliveryReport struct {
+	sync.Mutex
+	delivered int
+	failed    map[string]int
+}
+
+// collect counts the delivery reports of a producer until its events channel is closed.
+func (r *deliveryReport) collect(events chan kafka.Event, done chan struct{}) {
+	defer close(done)
+	for e := range events {
+		m, ok := e.(*kafka.Message)
+		if !ok {
+			continue
+		}
+		r.Lock()
+		if err := m.TopicPartition.Error; err != nil {
+			if r.failed == nil {
+				r.failed = map[string]int{}
+			}
+			r.failed[err.Error()]++
+		} else {
+			r.delivered++
+		}
+		r.Unlock()
+	}
+}
+
+// String returns the delivered and failed counts with the failures grouped by error.
+func (r *deliveryReport) String() string {
+	r.Lock()
+	defer r.Unlock()
+	total := 0
+	reasons := make([]string, 0, len(r.failed))
+	for reason, n := range r.failed {
+		total += n
+		reasons = append(reasons, reason)
+	}
+	sort.Slice(reasons, func(i, j int) bool { return r.failed[reasons[i]] > r.failed[reasons[j]] })
+	res := fmt.Sprintf("Delivered %d events, failed %d events", r.delivered, total)
+	for _, reason := range reasons {
+		res += fmt.Sprintf("\n  %d x %s", r.failed[reason], reason)
+	}
+	return res
+}
+
+// ratingMessage returns the message of a rating event keyed by its record id, so that
+// all events of a record go to the same partition and are ingested in order.
+func ratingMessage(topic string, contentType string, e *model.RatingEvent) (*kafka.Message, error) {
+	value, err := model.EncodeRatingEvent(e, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return &kafka.Message{
+		TopicPartition: kafka.TopicPartition{
+			Topic:     &topic,
+			Partition: kafka.PartitionAny,
+		},
+		Key:     []byte(e.RecordID),
+		Value:   value,
+		Headers: []kafka.Header{{Key: model.HeaderContentType, Value: []byte(contentType)}},
+	}, nil
+}
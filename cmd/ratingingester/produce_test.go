@@ -0,0 +1,25 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeliveryReport(t *testing.T) {
+	timedOut := kafka.NewError(kafka.ErrMsgTimedOut, "Local: Message timed out", false)
+	tooLarge := kafka.NewError(kafka.ErrMsgSizeTooLarge, "Broker: Message size too large", false)
+	events := make(chan kafka.Event, 10)
+	for _, err := range []error{nil, timedOut, nil, tooLarge, timedOut, nil} {
+		events <- &kafka.Message{TopicPartition: kafka.TopicPartition{Error: err}}
+	}
+	events <- kafka.NewError(kafka.ErrAllBrokersDown, "Local: All broker connections are down", false)
+	close(events)
+
+	r := &deliveryReport{}
+	done := make(chan struct{})
+	r.collect(events, done)
+	<-done
+	assert.Equal(t, "Delivered 3 events, failed 3 events\n  2 x Local: Message timed out\n  1 x Broker: Message size too large", r.String())
+}
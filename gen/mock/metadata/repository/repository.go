@@ -48,6 +48,19 @@ func (mr *MockmetadataRepositoryMockRecorder) Get(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockmetadataRepository)(nil).Get), arg0, arg1)
 }
 
+// GetBatch mocks base method.
+func (m *MockmetadataRepository) GetBatch(arg0 context.Context, arg1 []string) (map[string]*model.Metadata, error) {
+	ret := m.ctrl.Call(m, "GetBatch", arg0, arg1)
+	ret0, _ := ret[0].(map[string]*model.Metadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBatch indicates an expected call of GetBatch.
+func (mr *MockmetadataRepositoryMockRecorder) GetBatch(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatch", reflect.TypeOf((*MockmetadataRepository)(nil).GetBatch), arg0, arg1)
+}
+
 // Put mocks base method.
 func (m *MockmetadataRepository) Put(arg0 context.Context, arg1 *model.Metadata) error {
 	ret := m.ctrl.Call(m, "Put", arg0, arg1)
@@ -59,3 +72,53 @@ func (m *MockmetadataRepository) Put(arg0 context.Context, arg1 *model.Metadata)
 func (mr *MockmetadataRepositoryMockRecorder) Put(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockmetadataRepository)(nil).Put), arg0, arg1)
 }
+
+// Update mocks base method.
+func (m *MockmetadataRepository) Update(arg0 context.Context, arg1 *model.Metadata) error {
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockmetadataRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockmetadataRepository)(nil).Update), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockmetadataRepository) Delete(arg0 context.Context, arg1 string) error {
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockmetadataRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockmetadataRepository)(nil).Delete), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockmetadataRepository) List(arg0 context.Context, arg1 model.ListOptions) ([]*model.Metadata, error) {
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Metadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockmetadataRepositoryMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockmetadataRepository)(nil).List), arg0, arg1)
+}
+
+// Search mocks base method.
+func (m *MockmetadataRepository) Search(arg0 context.Context, arg1 model.SearchQuery) ([]model.SearchResult, error) {
+	ret := m.ctrl.Call(m, "Search", arg0, arg1)
+	ret0, _ := ret[0].([]model.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockmetadataRepositoryMockRecorder) Search(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockmetadataRepository)(nil).Search), arg0, arg1)
+}
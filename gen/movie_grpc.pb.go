@@ -19,8 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	MetadataService_GetMetadata_FullMethodName = "/MetadataService/GetMetadata"
-	MetadataService_PutMetadata_FullMethodName = "/MetadataService/PutMetadata"
+	MetadataService_GetMetadata_FullMethodName      = "/MetadataService/GetMetadata"
+	MetadataService_PutMetadata_FullMethodName      = "/MetadataService/PutMetadata"
+	MetadataService_UpdateMetadata_FullMethodName   = "/MetadataService/UpdateMetadata"
+	MetadataService_DeleteMetadata_FullMethodName   = "/MetadataService/DeleteMetadata"
+	MetadataService_ListMetadata_FullMethodName     = "/MetadataService/ListMetadata"
+	MetadataService_SearchMetadata_FullMethodName   = "/MetadataService/SearchMetadata"
+	MetadataService_BatchGetMetadata_FullMethodName = "/MetadataService/BatchGetMetadata"
 )
 
 // MetadataServiceClient is the client API for MetadataService service.
@@ -29,6 +34,11 @@ const (
 type MetadataServiceClient interface {
 	GetMetadata(ctx context.Context, in *GetMetadataRequest, opts ...grpc.CallOption) (*GetMetadataResponse, error)
 	PutMetadata(ctx context.Context, in *PutMetadataRequest, opts ...grpc.CallOption) (*PutMetadataResponse, error)
+	UpdateMetadata(ctx context.Context, in *UpdateMetadataRequest, opts ...grpc.CallOption) (*UpdateMetadataResponse, error)
+	DeleteMetadata(ctx context.Context, in *DeleteMetadataRequest, opts ...grpc.CallOption) (*DeleteMetadataResponse, error)
+	ListMetadata(ctx context.Context, in *ListMetadataRequest, opts ...grpc.CallOption) (*ListMetadataResponse, error)
+	SearchMetadata(ctx context.Context, in *SearchMetadataRequest, opts ...grpc.CallOption) (*SearchMetadataResponse, error)
+	BatchGetMetadata(ctx context.Context, in *BatchGetMetadataRequest, opts ...grpc.CallOption) (*BatchGetMetadataResponse, error)
 }
 
 type metadataServiceClient struct {
@@ -57,12 +67,62 @@ func (c *metadataServiceClient) PutMetadata(ctx context.Context, in *PutMetadata
 	return out, nil
 }
 
+func (c *metadataServiceClient) UpdateMetadata(ctx context.Context, in *UpdateMetadataRequest, opts ...grpc.CallOption) (*UpdateMetadataResponse, error) {
+	out := new(UpdateMetadataResponse)
+	err := c.cc.Invoke(ctx, MetadataService_UpdateMetadata_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) DeleteMetadata(ctx context.Context, in *DeleteMetadataRequest, opts ...grpc.CallOption) (*DeleteMetadataResponse, error) {
+	out := new(DeleteMetadataResponse)
+	err := c.cc.Invoke(ctx, MetadataService_DeleteMetadata_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) ListMetadata(ctx context.Context, in *ListMetadataRequest, opts ...grpc.CallOption) (*ListMetadataResponse, error) {
+	out := new(ListMetadataResponse)
+	err := c.cc.Invoke(ctx, MetadataService_ListMetadata_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) SearchMetadata(ctx context.Context, in *SearchMetadataRequest, opts ...grpc.CallOption) (*SearchMetadataResponse, error) {
+	out := new(SearchMetadataResponse)
+	err := c.cc.Invoke(ctx, MetadataService_SearchMetadata_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) BatchGetMetadata(ctx context.Context, in *BatchGetMetadataRequest, opts ...grpc.CallOption) (*BatchGetMetadataResponse, error) {
+	out := new(BatchGetMetadataResponse)
+	err := c.cc.Invoke(ctx, MetadataService_BatchGetMetadata_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MetadataServiceServer is the server API for MetadataService service.
 // All implementations must embed UnimplementedMetadataServiceServer
 // for forward compatibility
 type MetadataServiceServer interface {
 	GetMetadata(context.Context, *GetMetadataRequest) (*GetMetadataResponse, error)
 	PutMetadata(context.Context, *PutMetadataRequest) (*PutMetadataResponse, error)
+	UpdateMetadata(context.Context, *UpdateMetadataRequest) (*UpdateMetadataResponse, error)
+	DeleteMetadata(context.Context, *DeleteMetadataRequest) (*DeleteMetadataResponse, error)
+	ListMetadata(context.Context, *ListMetadataRequest) (*ListMetadataResponse, error)
+	SearchMetadata(context.Context, *SearchMetadataRequest) (*SearchMetadataResponse, error)
+	BatchGetMetadata(context.Context, *BatchGetMetadataRequest) (*BatchGetMetadataResponse, error)
 	mustEmbedUnimplementedMetadataServiceServer()
 }
 
@@ -76,6 +136,21 @@ func (UnimplementedMetadataServiceServer) GetMetadata(context.Context, *GetMetad
 func (UnimplementedMetadataServiceServer) PutMetadata(context.Context, *PutMetadataRequest) (*PutMetadataResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PutMetadata not implemented")
 }
+func (UnimplementedMetadataServiceServer) UpdateMetadata(context.Context, *UpdateMetadataRequest) (*UpdateMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMetadata not implemented")
+}
+func (UnimplementedMetadataServiceServer) DeleteMetadata(context.Context, *DeleteMetadataRequest) (*DeleteMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMetadata not implemented")
+}
+func (UnimplementedMetadataServiceServer) ListMetadata(context.Context, *ListMetadataRequest) (*ListMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMetadata not implemented")
+}
+func (UnimplementedMetadataServiceServer) SearchMetadata(context.Context, *SearchMetadataRequest) (*SearchMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchMetadata not implemented")
+}
+func (UnimplementedMetadataServiceServer) BatchGetMetadata(context.Context, *BatchGetMetadataRequest) (*BatchGetMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetMetadata not implemented")
+}
 func (UnimplementedMetadataServiceServer) mustEmbedUnimplementedMetadataServiceServer() {}
 
 // UnsafeMetadataServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -125,6 +200,96 @@ func _MetadataService_PutMetadata_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MetadataService_UpdateMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).UpdateMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetadataService_UpdateMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).UpdateMetadata(ctx, req.(*UpdateMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataService_DeleteMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).DeleteMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetadataService_DeleteMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).DeleteMetadata(ctx, req.(*DeleteMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataService_ListMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).ListMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetadataService_ListMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).ListMetadata(ctx, req.(*ListMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataService_SearchMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).SearchMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetadataService_SearchMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).SearchMetadata(ctx, req.(*SearchMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataService_BatchGetMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).BatchGetMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetadataService_BatchGetMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).BatchGetMetadata(ctx, req.(*BatchGetMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MetadataService_ServiceDesc is the grpc.ServiceDesc for MetadataService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -140,14 +305,39 @@ var MetadataService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PutMetadata",
 			Handler:    _MetadataService_PutMetadata_Handler,
 		},
+		{
+			MethodName: "UpdateMetadata",
+			Handler:    _MetadataService_UpdateMetadata_Handler,
+		},
+		{
+			MethodName: "DeleteMetadata",
+			Handler:    _MetadataService_DeleteMetadata_Handler,
+		},
+		{
+			MethodName: "ListMetadata",
+			Handler:    _MetadataService_ListMetadata_Handler,
+		},
+		{
+			MethodName: "SearchMetadata",
+			Handler:    _MetadataService_SearchMetadata_Handler,
+		},
+		{
+			MethodName: "BatchGetMetadata",
+			Handler:    _MetadataService_BatchGetMetadata_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "movie.proto",
 }
 
 const (
-	RatingService_GetAggregatedRating_FullMethodName = "/RatingService/GetAggregatedRating"
-	RatingService_PutRating_FullMethodName           = "/RatingService/PutRating"
+	RatingService_GetAggregatedRating_FullMethodName      = "/RatingService/GetAggregatedRating"
+	RatingService_PutRating_FullMethodName                = "/RatingService/PutRating"
+	RatingService_DeleteRating_FullMethodName             = "/RatingService/DeleteRating"
+	RatingService_GetRatingStats_FullMethodName           = "/RatingService/GetRatingStats"
+	RatingService_ListUserRatings_FullMethodName          = "/RatingService/ListUserRatings"
+	RatingService_GetTopRated_FullMethodName              = "/RatingService/GetTopRated"
+	RatingService_BatchGetAggregatedRating_FullMethodName = "/RatingService/BatchGetAggregatedRating"
 )
 
 // RatingServiceClient is the client API for RatingService service.
@@ -156,6 +346,11 @@ const (
 type RatingServiceClient interface {
 	GetAggregatedRating(ctx context.Context, in *GetAggregatedRatingRequest, opts ...grpc.CallOption) (*GetAggregatedRatingResponse, error)
 	PutRating(ctx context.Context, in *PutRatingRequest, opts ...grpc.CallOption) (*PutRatingResponse, error)
+	DeleteRating(ctx context.Context, in *DeleteRatingRequest, opts ...grpc.CallOption) (*DeleteRatingResponse, error)
+	GetRatingStats(ctx context.Context, in *GetRatingStatsRequest, opts ...grpc.CallOption) (*GetRatingStatsResponse, error)
+	ListUserRatings(ctx context.Context, in *ListUserRatingsRequest, opts ...grpc.CallOption) (*ListUserRatingsResponse, error)
+	GetTopRated(ctx context.Context, in *GetTopRatedRequest, opts ...grpc.CallOption) (*GetTopRatedResponse, error)
+	BatchGetAggregatedRating(ctx context.Context, in *BatchGetAggregatedRatingRequest, opts ...grpc.CallOption) (*BatchGetAggregatedRatingResponse, error)
 }
 
 type ratingServiceClient struct {
@@ -184,12 +379,62 @@ func (c *ratingServiceClient) PutRating(ctx context.Context, in *PutRatingReques
 	return out, nil
 }
 
+func (c *ratingServiceClient) DeleteRating(ctx context.Context, in *DeleteRatingRequest, opts ...grpc.CallOption) (*DeleteRatingResponse, error) {
+	out := new(DeleteRatingResponse)
+	err := c.cc.Invoke(ctx, RatingService_DeleteRating_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratingServiceClient) GetRatingStats(ctx context.Context, in *GetRatingStatsRequest, opts ...grpc.CallOption) (*GetRatingStatsResponse, error) {
+	out := new(GetRatingStatsResponse)
+	err := c.cc.Invoke(ctx, RatingService_GetRatingStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratingServiceClient) ListUserRatings(ctx context.Context, in *ListUserRatingsRequest, opts ...grpc.CallOption) (*ListUserRatingsResponse, error) {
+	out := new(ListUserRatingsResponse)
+	err := c.cc.Invoke(ctx, RatingService_ListUserRatings_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratingServiceClient) GetTopRated(ctx context.Context, in *GetTopRatedRequest, opts ...grpc.CallOption) (*GetTopRatedResponse, error) {
+	out := new(GetTopRatedResponse)
+	err := c.cc.Invoke(ctx, RatingService_GetTopRated_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratingServiceClient) BatchGetAggregatedRating(ctx context.Context, in *BatchGetAggregatedRatingRequest, opts ...grpc.CallOption) (*BatchGetAggregatedRatingResponse, error) {
+	out := new(BatchGetAggregatedRatingResponse)
+	err := c.cc.Invoke(ctx, RatingService_BatchGetAggregatedRating_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RatingServiceServer is the server API for RatingService service.
 // All implementations must embed UnimplementedRatingServiceServer
 // for forward compatibility
 type RatingServiceServer interface {
 	GetAggregatedRating(context.Context, *GetAggregatedRatingRequest) (*GetAggregatedRatingResponse, error)
 	PutRating(context.Context, *PutRatingRequest) (*PutRatingResponse, error)
+	DeleteRating(context.Context, *DeleteRatingRequest) (*DeleteRatingResponse, error)
+	GetRatingStats(context.Context, *GetRatingStatsRequest) (*GetRatingStatsResponse, error)
+	ListUserRatings(context.Context, *ListUserRatingsRequest) (*ListUserRatingsResponse, error)
+	GetTopRated(context.Context, *GetTopRatedRequest) (*GetTopRatedResponse, error)
+	BatchGetAggregatedRating(context.Context, *BatchGetAggregatedRatingRequest) (*BatchGetAggregatedRatingResponse, error)
 	mustEmbedUnimplementedRatingServiceServer()
 }
 
@@ -203,6 +448,21 @@ func (UnimplementedRatingServiceServer) GetAggregatedRating(context.Context, *Ge
 func (UnimplementedRatingServiceServer) PutRating(context.Context, *PutRatingRequest) (*PutRatingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PutRating not implemented")
 }
+func (UnimplementedRatingServiceServer) DeleteRating(context.Context, *DeleteRatingRequest) (*DeleteRatingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRating not implemented")
+}
+func (UnimplementedRatingServiceServer) GetRatingStats(context.Context, *GetRatingStatsRequest) (*GetRatingStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRatingStats not implemented")
+}
+func (UnimplementedRatingServiceServer) ListUserRatings(context.Context, *ListUserRatingsRequest) (*ListUserRatingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserRatings not implemented")
+}
+func (UnimplementedRatingServiceServer) GetTopRated(context.Context, *GetTopRatedRequest) (*GetTopRatedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTopRated not implemented")
+}
+func (UnimplementedRatingServiceServer) BatchGetAggregatedRating(context.Context, *BatchGetAggregatedRatingRequest) (*BatchGetAggregatedRatingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetAggregatedRating not implemented")
+}
 func (UnimplementedRatingServiceServer) mustEmbedUnimplementedRatingServiceServer() {}
 
 // UnsafeRatingServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -252,6 +512,96 @@ func _RatingService_PutRating_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RatingService_DeleteRating_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRatingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatingServiceServer).DeleteRating(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatingService_DeleteRating_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatingServiceServer).DeleteRating(ctx, req.(*DeleteRatingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatingService_GetRatingStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRatingStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatingServiceServer).GetRatingStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatingService_GetRatingStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatingServiceServer).GetRatingStats(ctx, req.(*GetRatingStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatingService_ListUserRatings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserRatingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatingServiceServer).ListUserRatings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatingService_ListUserRatings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatingServiceServer).ListUserRatings(ctx, req.(*ListUserRatingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatingService_GetTopRated_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopRatedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatingServiceServer).GetTopRated(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatingService_GetTopRated_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatingServiceServer).GetTopRated(ctx, req.(*GetTopRatedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatingService_BatchGetAggregatedRating_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetAggregatedRatingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatingServiceServer).BatchGetAggregatedRating(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatingService_BatchGetAggregatedRating_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatingServiceServer).BatchGetAggregatedRating(ctx, req.(*BatchGetAggregatedRatingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RatingService_ServiceDesc is the grpc.ServiceDesc for RatingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,13 +617,35 @@ var RatingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PutRating",
 			Handler:    _RatingService_PutRating_Handler,
 		},
+		{
+			MethodName: "DeleteRating",
+			Handler:    _RatingService_DeleteRating_Handler,
+		},
+		{
+			MethodName: "GetRatingStats",
+			Handler:    _RatingService_GetRatingStats_Handler,
+		},
+		{
+			MethodName: "ListUserRatings",
+			Handler:    _RatingService_ListUserRatings_Handler,
+		},
+		{
+			MethodName: "GetTopRated",
+			Handler:    _RatingService_GetTopRated_Handler,
+		},
+		{
+			MethodName: "BatchGetAggregatedRating",
+			Handler:    _RatingService_BatchGetAggregatedRating_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "movie.proto",
 }
 
 const (
-	MovieService_GetMovieDetails_FullMethodName = "/MovieService/GetMovieDetails"
+	MovieService_GetMovieDetails_FullMethodName      = "/MovieService/GetMovieDetails"
+	MovieService_BatchGetMovieDetails_FullMethodName = "/MovieService/BatchGetMovieDetails"
+	MovieService_RateMovie_FullMethodName            = "/MovieService/RateMovie"
 )
 
 // MovieServiceClient is the client API for MovieService service.
@@ -281,6 +653,8 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type MovieServiceClient interface {
 	GetMovieDetails(ctx context.Context, in *GetMovieDetailsRequest, opts ...grpc.CallOption) (*GetMovieDetailsResponse, error)
+	BatchGetMovieDetails(ctx context.Context, in *BatchGetMovieDetailsRequest, opts ...grpc.CallOption) (*BatchGetMovieDetailsResponse, error)
+	RateMovie(ctx context.Context, in *RateMovieRequest, opts ...grpc.CallOption) (*RateMovieResponse, error)
 }
 
 type movieServiceClient struct {
@@ -300,11 +674,31 @@ func (c *movieServiceClient) GetMovieDetails(ctx context.Context, in *GetMovieDe
 	return out, nil
 }
 
+func (c *movieServiceClient) BatchGetMovieDetails(ctx context.Context, in *BatchGetMovieDetailsRequest, opts ...grpc.CallOption) (*BatchGetMovieDetailsResponse, error) {
+	out := new(BatchGetMovieDetailsResponse)
+	err := c.cc.Invoke(ctx, MovieService_BatchGetMovieDetails_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *movieServiceClient) RateMovie(ctx context.Context, in *RateMovieRequest, opts ...grpc.CallOption) (*RateMovieResponse, error) {
+	out := new(RateMovieResponse)
+	err := c.cc.Invoke(ctx, MovieService_RateMovie_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MovieServiceServer is the server API for MovieService service.
 // All implementations must embed UnimplementedMovieServiceServer
 // for forward compatibility
 type MovieServiceServer interface {
 	GetMovieDetails(context.Context, *GetMovieDetailsRequest) (*GetMovieDetailsResponse, error)
+	BatchGetMovieDetails(context.Context, *BatchGetMovieDetailsRequest) (*BatchGetMovieDetailsResponse, error)
+	RateMovie(context.Context, *RateMovieRequest) (*RateMovieResponse, error)
 	mustEmbedUnimplementedMovieServiceServer()
 }
 
@@ -315,6 +709,12 @@ type UnimplementedMovieServiceServer struct {
 func (UnimplementedMovieServiceServer) GetMovieDetails(context.Context, *GetMovieDetailsRequest) (*GetMovieDetailsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMovieDetails not implemented")
 }
+func (UnimplementedMovieServiceServer) BatchGetMovieDetails(context.Context, *BatchGetMovieDetailsRequest) (*BatchGetMovieDetailsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetMovieDetails not implemented")
+}
+func (UnimplementedMovieServiceServer) RateMovie(context.Context, *RateMovieRequest) (*RateMovieResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RateMovie not implemented")
+}
 func (UnimplementedMovieServiceServer) mustEmbedUnimplementedMovieServiceServer() {}
 
 // UnsafeMovieServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -346,6 +746,42 @@ func _MovieService_GetMovieDetails_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MovieService_BatchGetMovieDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetMovieDetailsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovieServiceServer).BatchGetMovieDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MovieService_BatchGetMovieDetails_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovieServiceServer).BatchGetMovieDetails(ctx, req.(*BatchGetMovieDetailsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MovieService_RateMovie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateMovieRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovieServiceServer).RateMovie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MovieService_RateMovie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovieServiceServer).RateMovie(ctx, req.(*RateMovieRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MovieService_ServiceDesc is the grpc.ServiceDesc for MovieService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -357,6 +793,14 @@ var MovieService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMovieDetails",
 			Handler:    _MovieService_GetMovieDetails_Handler,
 		},
+		{
+			MethodName: "BatchGetMovieDetails",
+			Handler:    _MovieService_BatchGetMovieDetails_Handler,
+		},
+		{
+			MethodName: "RateMovie",
+			Handler:    _MovieService_RateMovie_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "movie.proto",
@@ -1,21 +1,22 @@
 package grpcutil
 
 import (
-	"context"
-	"math/rand"
-
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"movieexample.com/pkg/discovery"
 )
 
-func ServiceConnection(ctx context.Context, serviceName string, registry discovery.Registry) (*grpc.ClientConn, error) {
-	addrs, err := registry.ServiceAddress(ctx, serviceName)
-	if err != nil {
-		return nil, err
-	}
-	return grpc.Dial(addrs[rand.Intn(len(addrs))],
+// roundRobinConfig balances the calls of a connection across all resolved instances of a service.
+const roundRobinConfig = `{"loadBalancingConfig": [{"round_robin": {}}]}`
+
+// ServiceConnection creates a long-lived connection to all instances of a service found in the registry.
+// The instances are kept up to date as they come and go and calls are balanced across them round robin.
+// The connection is meant to be shared by all calls to the service and closed once no longer needed.
+func ServiceConnection(serviceName string, registry discovery.Registry) (*grpc.ClientConn, error) {
+	return grpc.Dial(Scheme+":///"+serviceName,
+		grpc.WithResolvers(NewResolverBuilder(registry)),
+		grpc.WithDefaultServiceConfig(roundRobinConfig),
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
 	)
@@ -0,0 +1,99 @@
+package grpcutil
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/resolver"
+	"movieexample.com/pkg/discovery"
+)
+
+// Scheme is the target scheme of services resolved through a discovery registry, e.g. registry:///rating.
+const Scheme = "registry"
+
+// watchRetryDelay defines how long a resolver waits before watching a service again after a failure.
+const watchRetryDelay = time.Second
+
+// resolverBuilder builds resolvers of service addresses backed by a discovery registry.
+type resolverBuilder struct {
+	registry discovery.Registry
+}
+
+// NewResolverBuilder creates a gRPC resolver builder for the registry scheme. The built resolvers
+// watch the instances of a service in the registry and update the addresses as they come and go.
+func NewResolverBuilder(registry discovery.Registry) resolver.Builder {
+	return &resolverBuilder{registry: registry}
+}
+
+// Build creates a resolver for the service named by the target endpoint.
+func (b *resolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &registryResolver{
+		registry:    b.registry,
+		serviceName: target.Endpoint(),
+		cc:          cc,
+		cancel:      cancel,
+	}
+	r.wg.Add(1)
+	go r.watch(ctx)
+	return r, nil
+}
+
+// Scheme returns the registry scheme.
+func (b *resolverBuilder) Scheme() string {
+	return Scheme
+}
+
+// registryResolver keeps the addresses of a client connection up to date with the instances of a service.
+type registryResolver struct {
+	registry    discovery.Registry
+	serviceName string
+	cc          resolver.ClientConn
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+}
+
+// ResolveNow does nothing, the addresses are updated as soon as the registry reports a change.
+func (r *registryResolver) ResolveNow(resolver.ResolveNowOptions) {}
+
+// Close stops watching the service.
+func (r *registryResolver) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+func (r *registryResolver) watch(ctx context.Context) {
+	defer r.wg.Done()
+	for {
+		updates, err := r.registry.Watch(ctx, r.serviceName)
+		if err == nil {
+			r.follow(updates)
+			return
+		} else if ctx.Err() != nil {
+			return
+		}
+		r.cc.ReportError(err)
+		select {
+		case <-time.After(watchRetryDelay):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// follow passes the address sets of a watch to the client connection until the watch ends.
+func (r *registryResolver) follow(updates <-chan []string) {
+	for addrs := range updates {
+		if len(addrs) == 0 {
+			r.cc.ReportError(discovery.ErrNotFound)
+			continue
+		}
+		state := resolver.State{}
+		for _, addr := range addrs {
+			state.Addresses = append(state.Addresses, resolver.Address{Addr: addr})
+		}
+		// An error means the balancer could not use the addresses, it asks to resolve again on its own.
+		_ = r.cc.UpdateState(state)
+	}
+}
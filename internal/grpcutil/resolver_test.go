@@ -0,0 +1,56 @@
+package grpcutil
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/resolver"
+	"movieexample.com/pkg/discovery/memory"
+)
+
+type fakeClientConn struct {
+	resolver.ClientConn
+	states chan resolver.State
+}
+
+func (c *fakeClientConn) UpdateState(s resolver.State) error {
+	c.states <- s
+	return nil
+}
+
+func (c *fakeClientConn) ReportError(error) {}
+
+func addrsOf(s resolver.State) []string {
+	var res []string
+	for _, a := range s.Addresses {
+		res = append(res, a.Addr)
+	}
+	return res
+}
+
+func TestResolverFollowsRegistry(t *testing.T) {
+	ctx := context.Background()
+	registry := memory.NewRegistry()
+	assert.NoError(t, registry.Register(ctx, "rating-1", "rating", "localhost:8082"))
+	assert.NoError(t, registry.Register(ctx, "rating-2", "rating", "localhost:8083"))
+
+	cc := &fakeClientConn{states: make(chan resolver.State, 1)}
+	b := NewResolverBuilder(registry)
+	target, err := url.Parse("registry:///rating")
+	assert.NoError(t, err)
+	r, err := b.Build(resolver.Target{URL: *target}, cc, resolver.BuildOptions{})
+	assert.NoError(t, err)
+	defer r.Close()
+	assert.Equal(t, []string{"localhost:8082", "localhost:8083"}, addrsOf(<-cc.states))
+
+	assert.NoError(t, registry.Deregister(ctx, "rating-1", "rating"))
+	select {
+	case s := <-cc.states:
+		assert.Equal(t, []string{"localhost:8083"}, addrsOf(s))
+	case <-time.After(time.Second):
+		t.Fatal("no state update after an instance left")
+	}
+}
@@ -1,5 +1,7 @@
 package main
 
+import "time"
+
 type apiConfig struct {
 	Port string `yaml:"port"`
 }
@@ -7,7 +9,16 @@ type jaegerConfig struct {
 	URL string `yaml:"url"`
 }
 
+type outboxConfig struct {
+	// Broker is the Kafka broker of the change events. They are only published in-process if it is empty.
+	Broker string `yaml:"broker"`
+	// Topic receives the metadata change events. They are only published in-process if it is empty.
+	Topic    string        `yaml:"topic"`
+	Interval time.Duration `yaml:"interval"`
+}
+
 type serverConfig struct {
 	API    apiConfig    `yaml:"api"`
 	Jaeger jaegerConfig `yaml:"jaeger"`
+	Outbox outboxConfig `yaml:"outbox"`
 }
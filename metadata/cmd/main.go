@@ -6,6 +6,9 @@ import (
 	"log"
 	"net"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
 	"go.opentelemetry.io/otel"
@@ -19,6 +22,8 @@ import (
 	"movieexample.com/metadata/internal/repository/memory"
 	"movieexample.com/pkg/discovery"
 	"movieexample.com/pkg/discovery/consul"
+	"movieexample.com/pkg/outbox"
+	outboxkafka "movieexample.com/pkg/outbox/kafka"
 	"movieexample.com/pkg/tracing"
 )
 
@@ -26,6 +31,11 @@ const serviceName = "metadata"
 
 func main() {
 
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	var wg sync.WaitGroup
+	wg.Add(1)
+
 	f, err := os.Open("configs/base.yaml")
 	if err != nil {
 		panic(err)
@@ -43,7 +53,7 @@ func main() {
 		panic(err)
 	}
 
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
 	instanceID := discovery.GenerateInstanceID(serviceName)
 
 	if err := registry.Register(ctx, instanceID, serviceName, fmt.Sprintf("localhost:%s", port)); err != nil {
@@ -59,12 +69,15 @@ func main() {
 		}
 	}()
 
-	defer registry.Deregister(ctx, instanceID, serviceName)
-
 	log.Printf("Starting %s on port %s", serviceName, port)
 	setJaegerAsProvider(ctx, cfg)
 	repo := memory.New()
 	ctrl := metadata.New(repo)
+	relay, closeRelay, err := outboxRelay(cfg.Outbox, repo.Outbox())
+	if err != nil {
+		panic(err)
+	}
+	defer closeRelay()
 	h := grpchandler.New(ctrl)
 	lis, err := net.Listen("tcp", fmt.Sprintf("localhost:%v", port))
 	if err != nil {
@@ -73,8 +86,29 @@ func main() {
 	srv := grpc.NewServer()
 	gen.RegisterMetadataServiceServer(srv, h)
 	reflection.Register(srv)
-	srv.Serve(lis)
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		relay.Run(ctx)
+		log.Println("metadata outbox relay stopped")
+	}()
+
+	go func() {
+		defer wg.Done()
+		s := <-quit
+		cancel()
+		log.Printf("shutdown signal received: %v\n", s)
+		registry.Deregister(ctx, instanceID, serviceName)
+		log.Println("metadata service deregistered")
+		srv.GracefulStop()
+		log.Println("metadata service stopped")
+
+	}()
+	if err := srv.Serve(lis); err != nil {
+		panic(err)
+	}
+	wg.Wait()
 }
 
 func setJaegerAsProvider(ctx context.Context, cfg serverConfig) {
@@ -92,3 +126,20 @@ func setJaegerAsProvider(ctx context.Context, cfg serverConfig) {
 	otel.SetTracerProvider(tp)
 	otel.SetTextMapPropagator(propagation.TraceContext{})
 }
+
+// outboxRelay returns the relay of the metadata change events to Kafka if an outbox topic is set
+// or to in-process subscribers otherwise, and a function that closes the publisher.
+func outboxRelay(cfg outboxConfig, store outbox.Store) (*outbox.Relay, func(), error) {
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	if cfg.Topic == "" || cfg.Broker == "" {
+		return outbox.NewRelay(store, outbox.NewLocalPublisher(), interval), func() {}, nil
+	}
+	publisher, err := outboxkafka.NewPublisher(cfg.Broker, cfg.Topic)
+	if err != nil {
+		return nil, nil, err
+	}
+	return outbox.NewRelay(store, publisher, interval), publisher.Close, nil
+}
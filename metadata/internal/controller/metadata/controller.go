@@ -2,7 +2,12 @@ package metadata
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"movieexample.com/metadata/internal/repository"
 	model "movieexample.com/metadata/pkg/model"
@@ -11,9 +16,29 @@ import (
 // ErrNotFound is returned when a requested record is not found.
 var ErrNotFound = errors.New("not found")
 
+// ErrVersionMismatch is returned when a write expects a version other than the stored one.
+var ErrVersionMismatch = errors.New("metadata version mismatch")
+
+// ErrInvalidArgument is returned when a request contains malformed parameters.
+var ErrInvalidArgument = errors.New("invalid argument")
+
+// Page size limits of metadata listings.
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// MaxBatchSize is the maximum number of ids in a batch request.
+const MaxBatchSize = 100
+
 type metadataRepository interface {
 	Get(context.Context, string) (*model.Metadata, error)
+	GetBatch(context.Context, []string) (map[string]*model.Metadata, error)
 	Put(context.Context, *model.Metadata) error
+	Update(context.Context, *model.Metadata) error
+	Delete(context.Context, string) error
+	List(context.Context, model.ListOptions) ([]*model.Metadata, error)
+	Search(context.Context, model.SearchQuery) ([]model.SearchResult, error)
 }
 
 type Controller struct {
@@ -36,8 +61,154 @@ func (c *Controller) Get(ctx context.Context, id string) (*model.Metadata, error
 	return res, nil
 }
 
+// BatchGet returns movie metadata for the given ids keyed by id.
+// Ids without metadata are absent from the result.
+func (c *Controller) BatchGet(ctx context.Context, ids []string) (map[string]*model.Metadata, error) {
+	if len(ids) > MaxBatchSize {
+		return nil, fmt.Errorf("%w: more than %d ids", ErrInvalidArgument, MaxBatchSize)
+	}
+	seen := make(map[string]bool, len(ids))
+	var unique []string
+	for _, id := range ids {
+		if id == "" {
+			return nil, fmt.Errorf("%w: empty id", ErrInvalidArgument)
+		}
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return c.repo.GetBatch(ctx, unique)
+}
 
-// Put writes movie metadata to repository.
+// Put writes movie metadata to repository and sets its new version.
+// m must carry the stored version, zero for new metadata, and ErrVersionMismatch
+// is returned otherwise.
 func (c *Controller) Put(ctx context.Context, m *model.Metadata) error {
-	return c.repo.Put(ctx, m)
-}
\ No newline at end of file
+	if err := validate(m); err != nil {
+		return err
+	}
+	if err := c.repo.Put(ctx, m); err != nil && errors.Is(err, repository.ErrVersionMismatch) {
+		return ErrVersionMismatch
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Update replaces existing movie metadata and sets its new version.
+// m must carry the version the caller expects to replace. It returns ErrNotFound
+// if there is none and ErrVersionMismatch if the stored version differs.
+func (c *Controller) Update(ctx context.Context, m *model.Metadata) error {
+	if m.Version == 0 {
+		return fmt.Errorf("%w: update requires the expected version", ErrInvalidArgument)
+	}
+	if err := validate(m); err != nil {
+		return err
+	}
+	if err := c.repo.Update(ctx, m); err != nil && errors.Is(err, repository.ErrNotFound) {
+		return ErrNotFound
+	} else if err != nil && errors.Is(err, repository.ErrVersionMismatch) {
+		return ErrVersionMismatch
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Delete removes movie metadata by id.
+func (c *Controller) Delete(ctx context.Context, id string) error {
+	if err := c.repo.Delete(ctx, id); err != nil && errors.Is(err, repository.ErrNotFound) {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// List returns a page of movie metadata together with the token of the next page.
+// The next page token is empty when there are no more records.
+func (c *Controller) List(ctx context.Context, pageSize int, pageToken string, orderBy model.OrderBy, descending bool) ([]*model.Metadata, string, error) {
+	if orderBy == "" {
+		orderBy = model.OrderByID
+	}
+	if orderBy != model.OrderByID && orderBy != model.OrderByTitle {
+		return nil, "", fmt.Errorf("%w: unsupported order by %q", ErrInvalidArgument, orderBy)
+	}
+	if pageSize < 0 {
+		return nil, "", fmt.Errorf("%w: negative page size", ErrInvalidArgument)
+	} else if pageSize == 0 {
+		pageSize = DefaultPageSize
+	} else if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+	opts := model.ListOptions{Limit: pageSize + 1, OrderBy: orderBy, Descending: descending}
+	if pageToken != "" {
+		cursor, err := decodePageToken(pageToken)
+		if err != nil || cursor.OrderBy != orderBy || cursor.Descending != descending {
+			return nil, "", fmt.Errorf("%w: malformed page token", ErrInvalidArgument)
+		}
+		opts.After = cursor
+	}
+	res, err := c.repo.List(ctx, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(res) <= pageSize {
+		return res, "", nil
+	}
+	res = res[:pageSize]
+	next, err := encodePageToken(model.CursorOf(res[pageSize-1], orderBy, descending))
+	if err != nil {
+		return nil, "", err
+	}
+	return res, next, nil
+}
+
+// Search returns movie metadata matching the query ordered by relevance.
+func (c *Controller) Search(ctx context.Context, q model.SearchQuery) ([]model.SearchResult, error) {
+	if strings.TrimSpace(q.Text) == "" && q.Director == "" && q.Genre == "" {
+		return nil, fmt.Errorf("%w: empty search query", ErrInvalidArgument)
+	}
+	if q.Limit < 0 {
+		return nil, fmt.Errorf("%w: negative limit", ErrInvalidArgument)
+	} else if q.Limit == 0 {
+		q.Limit = DefaultPageSize
+	} else if q.Limit > MaxPageSize {
+		q.Limit = MaxPageSize
+	}
+	return c.repo.Search(ctx, q)
+}
+
+// validate checks the fields of movie metadata that have a required format.
+func validate(m *model.Metadata) error {
+	if m.ReleaseDate != "" {
+		if _, err := time.Parse(model.ReleaseDateLayout, m.ReleaseDate); err != nil {
+			return fmt.Errorf("%w: release date must be in YYYY-MM-DD form", ErrInvalidArgument)
+		}
+	}
+	if m.RuntimeMinutes < 0 {
+		return fmt.Errorf("%w: negative runtime", ErrInvalidArgument)
+	}
+	return nil
+}
+
+func encodePageToken(c *model.Cursor) (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodePageToken(token string) (*model.Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	var c model.Cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -50,4 +50,175 @@ func TestController(t *testing.T) {
 			assert.Equal(t, tt.wantErr, err, tt.name)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestControllerList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repoMock := gen.NewMockmetadataRepository(ctrl)
+	c := New(repoMock)
+	ctx := context.Background()
+
+	first := []*model.Metadata{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+	repoMock.EXPECT().List(ctx, model.ListOptions{Limit: 3, OrderBy: model.OrderByID}).Return(first, nil)
+	res, next, err := c.List(ctx, 2, "", "", false)
+	assert.NoError(t, err)
+	assert.Equal(t, first[:2], res)
+	assert.NotEmpty(t, next)
+
+	_, _, err = c.List(ctx, 2, next, model.OrderByID, true)
+	assert.ErrorIs(t, err, ErrInvalidArgument, "a token of an ascending listing does not continue a descending one")
+	_, _, err = c.List(ctx, 2, next, model.OrderByTitle, false)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	repoMock.EXPECT().List(ctx, model.ListOptions{Limit: 3, OrderBy: model.OrderByID, After: model.CursorOf(first[1], model.OrderByID, false)}).Return(first[2:], nil)
+	res, next, err = c.List(ctx, 2, next, model.OrderByID, false)
+	assert.NoError(t, err)
+	assert.Equal(t, first[2:], res)
+	assert.Empty(t, next)
+
+	_, _, err = c.List(ctx, 2, "not a token", model.OrderByID, false)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, _, err = c.List(ctx, 2, "", "director", false)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestControllerDelete(t *testing.T) {
+	tests := []struct {
+		name       string
+		expRepoErr error
+		wantErr    error
+	}{
+		{
+			name:       "not found",
+			expRepoErr: repository.ErrNotFound,
+			wantErr:    ErrNotFound,
+		},
+		{
+			name:       "unexpected error",
+			expRepoErr: errors.New("unexpected error"),
+			wantErr:    errors.New("unexpected error"),
+		},
+		{
+			name: "success",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repoMock := gen.NewMockmetadataRepository(ctrl)
+			c := New(repoMock)
+			ctx := context.Background()
+			id := "id"
+			repoMock.EXPECT().Delete(ctx, id).Return(tt.expRepoErr)
+			err := c.Delete(ctx, id)
+			assert.Equal(t, tt.wantErr, err, tt.name)
+		})
+	}
+}
+
+func TestControllerUpdate(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    int64
+		expRepo    bool
+		expRepoErr error
+		wantErr    error
+	}{
+		{
+			name:    "no expected version",
+			wantErr: ErrInvalidArgument,
+		},
+		{
+			name:       "version mismatch",
+			version:    1,
+			expRepo:    true,
+			expRepoErr: repository.ErrVersionMismatch,
+			wantErr:    ErrVersionMismatch,
+		},
+		{
+			name:       "not found",
+			version:    1,
+			expRepo:    true,
+			expRepoErr: repository.ErrNotFound,
+			wantErr:    ErrNotFound,
+		},
+		{
+			name:    "success",
+			version: 1,
+			expRepo: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repoMock := gen.NewMockmetadataRepository(ctrl)
+			c := New(repoMock)
+			ctx := context.Background()
+			m := &model.Metadata{ID: "id", Version: tt.version}
+			if tt.expRepo {
+				repoMock.EXPECT().Update(ctx, m).Return(tt.expRepoErr)
+			}
+			err := c.Update(ctx, m)
+			if tt.wantErr == nil {
+				assert.NoError(t, err, tt.name)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr, tt.name)
+			}
+		})
+	}
+}
+
+func TestControllerPutValidates(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       *model.Metadata
+		wantErr bool
+	}{
+		{name: "release date", m: &model.Metadata{ID: "id", ReleaseDate: "1977-05-25"}},
+		{name: "no release date", m: &model.Metadata{ID: "id"}},
+		{name: "release date in another form", m: &model.Metadata{ID: "id", ReleaseDate: "25.05.1977"}, wantErr: true},
+		{name: "impossible release date", m: &model.Metadata{ID: "id", ReleaseDate: "1977-02-30"}, wantErr: true},
+		{name: "runtime", m: &model.Metadata{ID: "id", RuntimeMinutes: 121}},
+		{name: "negative runtime", m: &model.Metadata{ID: "id", RuntimeMinutes: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repoMock := gen.NewMockmetadataRepository(ctrl)
+			c := New(repoMock)
+			ctx := context.Background()
+			if !tt.wantErr {
+				repoMock.EXPECT().Put(ctx, tt.m).Return(nil)
+			}
+			err := c.Put(ctx, tt.m)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidArgument)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestControllerBatchGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repoMock := gen.NewMockmetadataRepository(ctrl)
+	c := New(repoMock)
+	ctx := context.Background()
+
+	want := map[string]*model.Metadata{"1": {ID: "1"}}
+	repoMock.EXPECT().GetBatch(ctx, []string{"1", "2"}).Return(want, nil)
+	res, err := c.BatchGet(ctx, []string{"1", "2", "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, want, res)
+
+	_, err = c.BatchGet(ctx, []string{"1", ""})
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = c.BatchGet(ctx, make([]string, MaxBatchSize+1))
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
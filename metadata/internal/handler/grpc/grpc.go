@@ -33,14 +33,118 @@ func (h *Handler) GetMetadata(ctx context.Context, in *gen.GetMetadataRequest) (
 	return &gen.GetMetadataResponse{Metadata: model.MetadataToProto(m)}, nil
 }
 
+// BatchGetMetadata returns movie metadata for several ids.
+// Ids without metadata get a NotFound item error instead of failing the request.
+func (h *Handler) BatchGetMetadata(ctx context.Context, req *gen.BatchGetMetadataRequest) (*gen.BatchGetMetadataResponse, error) {
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "nil req")
+	}
+	res, err := h.ctrl.BatchGet(ctx, req.Ids)
+	if err != nil && errors.Is(err, metadata.ErrInvalidArgument) {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	resp := &gen.BatchGetMetadataResponse{}
+	for _, id := range req.Ids {
+		r := &gen.MetadataResult{Id: id}
+		if m, ok := res[id]; ok {
+			r.Metadata = model.MetadataToProto(m)
+		} else {
+			r.Error = &gen.ItemError{Code: int32(codes.NotFound), Message: metadata.ErrNotFound.Error()}
+		}
+		resp.Results = append(resp.Results, r)
+	}
+	return resp, nil
+}
 
 // PutMetadata puts movie metadata to repository.
 func (h *Handler) PutMetadata(ctx context.Context, req *gen.PutMetadataRequest) (*gen.PutMetadataResponse, error) {
 	if req == nil || req.Metadata == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "nil req or metadata")
 	}
-	if err := h.ctrl.Put(ctx, model.MetadataFromProto(req.Metadata)); err != nil {
+	m := model.MetadataFromProto(req.Metadata)
+	err := h.ctrl.Put(ctx, m)
+	if err != nil && errors.Is(err, metadata.ErrInvalidArgument) {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	} else if err != nil && errors.Is(err, metadata.ErrVersionMismatch) {
+		return nil, status.Errorf(codes.Aborted, err.Error())
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	return &gen.PutMetadataResponse{Version: m.Version}, nil
+}
+
+// UpdateMetadata replaces existing movie metadata.
+func (h *Handler) UpdateMetadata(ctx context.Context, req *gen.UpdateMetadataRequest) (*gen.UpdateMetadataResponse, error) {
+	if req == nil || req.Metadata == nil || req.Metadata.Id == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "nil req or metadata or empty id")
+	}
+	m := model.MetadataFromProto(req.Metadata)
+	err := h.ctrl.Update(ctx, m)
+	if err != nil && errors.Is(err, metadata.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, err.Error())
+	} else if err != nil && errors.Is(err, metadata.ErrInvalidArgument) {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	} else if err != nil && errors.Is(err, metadata.ErrVersionMismatch) {
+		return nil, status.Errorf(codes.Aborted, err.Error())
+	} else if err != nil {
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
-	return &gen.PutMetadataResponse{}, nil
-}
\ No newline at end of file
+	return &gen.UpdateMetadataResponse{Version: m.Version}, nil
+}
+
+// DeleteMetadata removes movie metadata by id.
+func (h *Handler) DeleteMetadata(ctx context.Context, req *gen.DeleteMetadataRequest) (*gen.DeleteMetadataResponse, error) {
+	if req == nil || req.Id == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "nil req or empty id")
+	}
+	err := h.ctrl.Delete(ctx, req.Id)
+	if err != nil && errors.Is(err, metadata.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	return &gen.DeleteMetadataResponse{}, nil
+}
+
+// ListMetadata returns a page of movie metadata.
+func (h *Handler) ListMetadata(ctx context.Context, req *gen.ListMetadataRequest) (*gen.ListMetadataResponse, error) {
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "nil req")
+	}
+	res, next, err := h.ctrl.List(ctx, int(req.PageSize), req.PageToken, model.OrderBy(req.OrderBy), req.Descending)
+	if err != nil && errors.Is(err, metadata.ErrInvalidArgument) {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	resp := &gen.ListMetadataResponse{NextPageToken: next}
+	for _, m := range res {
+		resp.Metadata = append(resp.Metadata, model.MetadataToProto(m))
+	}
+	return resp, nil
+}
+
+// SearchMetadata returns movie metadata matching a full-text query.
+func (h *Handler) SearchMetadata(ctx context.Context, req *gen.SearchMetadataRequest) (*gen.SearchMetadataResponse, error) {
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "nil req")
+	}
+	res, err := h.ctrl.Search(ctx, model.SearchQuery{
+		Text:     req.Query,
+		Director: req.Director,
+		Genre:    req.Genre,
+		Limit:    int(req.Limit),
+	})
+	if err != nil && errors.Is(err, metadata.ErrInvalidArgument) {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	resp := &gen.SearchMetadataResponse{}
+	for _, r := range res {
+		resp.Results = append(resp.Results, &gen.SearchResult{Metadata: model.MetadataToProto(r.Metadata), Score: r.Score})
+	}
+	return resp, nil
+}
@@ -0,0 +1,62 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"movieexample.com/gen"
+	"movieexample.com/metadata/internal/controller/metadata"
+	"movieexample.com/metadata/internal/repository/memory"
+)
+
+func TestUpdateMetadataVersions(t *testing.T) {
+	ctx := context.Background()
+	h := New(metadata.New(memory.New()))
+	put, err := h.PutMetadata(ctx, &gen.PutMetadataRequest{Metadata: &gen.Metadata{Id: "1", Title: "Star Wars"}})
+	assert.NoError(t, err)
+
+	_, err = h.UpdateMetadata(ctx, &gen.UpdateMetadataRequest{Metadata: &gen.Metadata{Id: "1", Title: "Episode IV"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "an update must carry the expected version")
+
+	_, err = h.UpdateMetadata(ctx, &gen.UpdateMetadataRequest{Metadata: &gen.Metadata{Id: "1", Title: "Episode IV", Version: put.Version + 1}})
+	assert.Equal(t, codes.Aborted, status.Code(err))
+
+	resp, err := h.UpdateMetadata(ctx, &gen.UpdateMetadataRequest{Metadata: &gen.Metadata{Id: "1", Title: "Episode IV", Version: put.Version}})
+	assert.NoError(t, err)
+	assert.Equal(t, put.Version+1, resp.Version)
+
+	_, err = h.UpdateMetadata(ctx, &gen.UpdateMetadataRequest{Metadata: &gen.Metadata{Id: "1", Title: "A New Hope", Version: put.Version}})
+	assert.Equal(t, codes.Aborted, status.Code(err), "a stale version loses against a concurrent update")
+}
+
+func TestBatchGetMetadata(t *testing.T) {
+	ctx := context.Background()
+	h := New(metadata.New(memory.New()))
+	for _, id := range []string{"1", "2"} {
+		_, err := h.PutMetadata(ctx, &gen.PutMetadataRequest{Metadata: &gen.Metadata{Id: id, Title: "Movie " + id}})
+		assert.NoError(t, err)
+	}
+
+	resp, err := h.BatchGetMetadata(ctx, &gen.BatchGetMetadataRequest{Ids: []string{"2", "missing", "1", "2"}})
+	assert.NoError(t, err)
+	var ids, titles []string
+	var codesOf []codes.Code
+	for _, r := range resp.Results {
+		ids = append(ids, r.Id)
+		titles = append(titles, r.GetMetadata().GetTitle())
+		codesOf = append(codesOf, codes.Code(r.GetError().GetCode()))
+	}
+	assert.Equal(t, []string{"2", "missing", "1", "2"}, ids, "one result per requested id in request order")
+	assert.Equal(t, []string{"Movie 2", "", "Movie 1", "Movie 2"}, titles)
+	assert.Equal(t, []codes.Code{codes.OK, codes.NotFound, codes.OK, codes.OK}, codesOf)
+
+	_, err = h.BatchGetMetadata(ctx, &gen.BatchGetMetadataRequest{Ids: []string{"1", ""}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = h.BatchGetMetadata(ctx, &gen.BatchGetMetadataRequest{Ids: make([]string, metadata.MaxBatchSize+1)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = h.BatchGetMetadata(ctx, nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"log"
 	"net/http"
+	"strconv"
 
 	"movieexample.com/metadata/internal/controller/metadata"
 	"movieexample.com/metadata/internal/repository"
+	"movieexample.com/metadata/pkg/model"
 )
 
 
@@ -42,4 +44,40 @@ func (h *Handler) GetMetadata(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-}
\ No newline at end of file
+}
+
+// ListMetadata handles listing requests of movie metadata.
+func (h *Handler) ListMetadata(w http.ResponseWriter, r *http.Request) {
+	pageSize := 0
+	if v := r.FormValue("page_size"); v != "" {
+		var err error
+		if pageSize, err = strconv.Atoi(v); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	descending := false
+	if v := r.FormValue("desc"); v != "" {
+		var err error
+		if descending, err = strconv.ParseBool(v); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	res, next, err := h.ctrl.List(r.Context(), pageSize, r.FormValue("page_token"), model.OrderBy(r.FormValue("order_by")), descending)
+	if err != nil && errors.Is(err, metadata.ErrInvalidArgument) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Printf("Repository list error: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp := struct {
+		Metadata      []*model.Metadata `json:"metadata"`
+		NextPageToken string            `json:"next_page_token,omitempty"`
+	}{res, next}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("JSON encode error: %v\n", err)
+	}
+}
@@ -2,4 +2,7 @@ package repository
 
 import "errors"
 
-var ErrNotFound = errors.New("not found")
\ No newline at end of file
+var ErrNotFound = errors.New("not found")
+
+// ErrVersionMismatch is returned when a write expects a version other than the stored one.
+var ErrVersionMismatch = errors.New("version mismatch")
@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"movieexample.com/metadata/internal/repository"
+	model "movieexample.com/metadata/pkg/model"
+)
+
+// Field weights used for relevance scoring.
+const (
+	titleWeight       = 3
+	directorWeight    = 2
+	descriptionWeight = 1
+	// prefixWeight discounts terms matched by prefix rather than exactly.
+	prefixWeight = 0.5
+)
+
+// index defines an inverted index over movie metadata.
+type index struct {
+	// postings maps a term to the weighted term frequency per movie id.
+	postings map[string]map[string]float64
+	// terms is the sorted vocabulary used for prefix lookups.
+	terms []string
+	// docTerms keeps the terms indexed per movie id so they can be removed.
+	docTerms map[string][]string
+}
+
+func newIndex() *index {
+	return &index{
+		postings: map[string]map[string]float64{},
+		docTerms: map[string][]string{},
+	}
+}
+
+// add indexes movie metadata, replacing a previously indexed version of it.
+func (x *index) add(m *model.Metadata) {
+	x.remove(m.ID)
+	freqs := map[string]float64{}
+	for _, t := range repository.Tokenize(m.Title) {
+		freqs[t] += titleWeight
+	}
+	for _, t := range repository.Tokenize(m.Director) {
+		freqs[t] += directorWeight
+	}
+	for _, t := range repository.Tokenize(m.Description) {
+		freqs[t] += descriptionWeight
+	}
+	terms := make([]string, 0, len(freqs))
+	for t, f := range freqs {
+		if _, ok := x.postings[t]; !ok {
+			x.postings[t] = map[string]float64{}
+			i := sort.SearchStrings(x.terms, t)
+			x.terms = append(x.terms, "")
+			copy(x.terms[i+1:], x.terms[i:])
+			x.terms[i] = t
+		}
+		x.postings[t][m.ID] = f
+		terms = append(terms, t)
+	}
+	x.docTerms[m.ID] = terms
+}
+
+// remove drops movie metadata from the index.
+func (x *index) remove(id string) {
+	for _, t := range x.docTerms[id] {
+		delete(x.postings[t], id)
+		if len(x.postings[t]) > 0 {
+			continue
+		}
+		delete(x.postings, t)
+		i := sort.SearchStrings(x.terms, t)
+		x.terms = append(x.terms[:i], x.terms[i+1:]...)
+	}
+	delete(x.docTerms, id)
+}
+
+// search returns the relevance scores of the movies matching all tokens.
+// The last token also matches indexed terms it is a prefix of.
+func (x *index) search(tokens []string) map[string]float64 {
+	var res map[string]float64
+	for i, token := range tokens {
+		scores := map[string]float64{}
+		x.score(scores, token, 1)
+		if i == len(tokens)-1 {
+			for j := sort.SearchStrings(x.terms, token); j < len(x.terms) && strings.HasPrefix(x.terms[j], token); j++ {
+				if x.terms[j] != token {
+					x.score(scores, x.terms[j], prefixWeight)
+				}
+			}
+		}
+		if res == nil {
+			res = scores
+			continue
+		}
+		for id, s := range res {
+			if ts, ok := scores[id]; ok {
+				res[id] = s + ts
+			} else {
+				delete(res, id)
+			}
+		}
+	}
+	return res
+}
+
+// score adds the tf-idf weight of a term to the scores of the movies containing it.
+func (x *index) score(scores map[string]float64, term string, weight float64) {
+	postings := x.postings[term]
+	if len(postings) == 0 {
+		return
+	}
+	idf := math.Log(1 + float64(len(x.docTerms))/float64(len(postings)))
+	for id, tf := range postings {
+		scores[id] += weight * tf * idf
+	}
+}
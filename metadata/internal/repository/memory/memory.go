@@ -2,39 +2,219 @@ package memory
 
 import (
 	"context"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 
 	"movieexample.com/metadata/internal/repository"
 	model "movieexample.com/metadata/pkg/model"
+	"movieexample.com/pkg/outbox"
 )
 
 // Repository defines a memory movie metadata repository.
 type Repository struct {
 	sync.RWMutex
-	data map[string]*model.Metadata
+	data   map[string]*model.Metadata
+	index  *index
+	outbox *outbox.MemoryStore
 }
 
 // New creates a new memory repository.
 func New() *Repository {
 	return &Repository{
-		data: make(map[string]*model.Metadata),
+		data:   make(map[string]*model.Metadata),
+		index:  newIndex(),
+		outbox: outbox.NewMemoryStore(),
 	}
 }
 
+// Outbox returns the outbox of the metadata change events.
+func (r *Repository) Outbox() *outbox.MemoryStore {
+	return r.outbox
+}
+
 // Get retrieves movie metadata for by movie id.
 func (r *Repository) Get(_ context.Context, id string) (*model.Metadata, error) {
 	r.RLock()
 	defer r.RUnlock()
 	if val, ok := r.data[id]; ok {
-		return val, nil
+		return copyMetadata(val), nil
 	}
 	return nil, repository.ErrNotFound
 }
 
-// Put adds movie metadata for a given movie id.
+// GetBatch retrieves movie metadata for the given movie ids keyed by id.
+// Ids without metadata are absent from the result.
+func (r *Repository) GetBatch(_ context.Context, ids []string) (map[string]*model.Metadata, error) {
+	r.RLock()
+	defer r.RUnlock()
+	res := make(map[string]*model.Metadata, len(ids))
+	for _, id := range ids {
+		if val, ok := r.data[id]; ok {
+			res[id] = copyMetadata(val)
+		}
+	}
+	return res, nil
+}
+
+// Put adds movie metadata for a given movie id or replaces it and bumps its version.
+// It returns ErrVersionMismatch unless m carries the stored version, zero for new metadata.
 func (r *Repository) Put(_ context.Context, m *model.Metadata) error {
 	r.Lock()
 	defer r.Unlock()
-	r.data[m.ID] = m
+	return r.write(m, false)
+}
+
+// Update replaces existing movie metadata and bumps its version.
+// It returns ErrNotFound if there is no metadata for the movie id and ErrVersionMismatch
+// unless m carries the stored version.
+func (r *Repository) Update(_ context.Context, m *model.Metadata) error {
+	r.Lock()
+	defer r.Unlock()
+	return r.write(m, true)
+}
+
+func (r *Repository) write(m *model.Metadata, mustExist bool) error {
+	var version int64
+	if old, ok := r.data[m.ID]; ok {
+		version = old.Version
+	} else if mustExist {
+		return repository.ErrNotFound
+	}
+	if m.Version != version {
+		return repository.ErrVersionMismatch
+	}
+	e, err := repository.MetadataChangedEvent(m.ID, version+1, false)
+	if err != nil {
+		return err
+	}
+	stored := copyMetadata(m)
+	stored.Version = version + 1
+	r.data[m.ID] = stored
+	r.index.add(stored)
+	r.outbox.Add(e)
+	m.Version = stored.Version
+	return nil
+}
+
+// copyMetadata returns a deep copy of movie metadata so that the stored copy
+// and the ones handed to callers do not change with each other.
+func copyMetadata(m *model.Metadata) *model.Metadata {
+	c := *m
+	c.Genres = slices.Clone(m.Genres)
+	c.Cast = slices.Clone(m.Cast)
+	c.Crew = slices.Clone(m.Crew)
+	c.SpokenLanguages = slices.Clone(m.SpokenLanguages)
+	c.ExternalIDs = maps.Clone(m.ExternalIDs)
+	return &c
+}
+
+// Delete removes movie metadata by movie id.
+func (r *Repository) Delete(_ context.Context, id string) error {
+	r.Lock()
+	defer r.Unlock()
+	if _, ok := r.data[id]; !ok {
+		return repository.ErrNotFound
+	}
+	e, err := repository.MetadataChangedEvent(id, 0, true)
+	if err != nil {
+		return err
+	}
+	delete(r.data, id)
+	r.index.remove(id)
+	r.outbox.Add(e)
 	return nil
 }
+
+// List returns movie metadata sorted and paginated according to the given options.
+func (r *Repository) List(_ context.Context, opts model.ListOptions) ([]*model.Metadata, error) {
+	r.RLock()
+	defer r.RUnlock()
+	res := make([]*model.Metadata, 0, len(r.data))
+	for _, m := range r.data {
+		if opts.After != nil && !after(model.CursorOf(m, opts.OrderBy, opts.Descending), opts.After, opts.Descending) {
+			continue
+		}
+		res = append(res, m)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return after(model.CursorOf(res[j], opts.OrderBy, opts.Descending), model.CursorOf(res[i], opts.OrderBy, opts.Descending), opts.Descending)
+	})
+	if opts.Limit > 0 && len(res) > opts.Limit {
+		res = res[:opts.Limit]
+	}
+	for i, m := range res {
+		res[i] = copyMetadata(m)
+	}
+	return res, nil
+}
+
+// Search returns movie metadata matching the query ordered by relevance.
+func (r *Repository) Search(_ context.Context, q model.SearchQuery) ([]model.SearchResult, error) {
+	r.RLock()
+	defer r.RUnlock()
+	var res []model.SearchResult
+	if tokens := repository.Tokenize(q.Text); len(tokens) > 0 {
+		for id, score := range r.index.search(tokens) {
+			res = append(res, model.SearchResult{Metadata: r.data[id], Score: score})
+		}
+	} else {
+		for _, m := range r.data {
+			res = append(res, model.SearchResult{Metadata: m})
+		}
+	}
+	filtered := res[:0]
+	for _, sr := range res {
+		if matchesFilters(sr.Metadata, q) {
+			filtered = append(filtered, sr)
+		}
+	}
+	res = filtered
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Score != res[j].Score {
+			return res[i].Score > res[j].Score
+		}
+		if res[i].Metadata.Title != res[j].Metadata.Title {
+			return res[i].Metadata.Title < res[j].Metadata.Title
+		}
+		return res[i].Metadata.ID < res[j].Metadata.ID
+	})
+	if q.Limit > 0 && len(res) > q.Limit {
+		res = res[:q.Limit]
+	}
+	for i := range res {
+		res[i].Metadata = copyMetadata(res[i].Metadata)
+	}
+	return res, nil
+}
+
+func matchesFilters(m *model.Metadata, q model.SearchQuery) bool {
+	if q.Director != "" && !strings.EqualFold(m.Director, q.Director) {
+		return false
+	}
+	if q.Genre == "" {
+		return true
+	}
+	for _, g := range m.Genres {
+		if strings.EqualFold(g, q.Genre) {
+			return true
+		}
+	}
+	return false
+}
+
+// after reports whether a comes after b in the listing order.
+func after(a, b *model.Cursor, descending bool) bool {
+	if a.Key == b.Key {
+		if descending {
+			return a.ID < b.ID
+		}
+		return a.ID > b.ID
+	}
+	if descending {
+		return a.Key < b.Key
+	}
+	return a.Key > b.Key
+}
@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"movieexample.com/metadata/internal/repository"
+	"movieexample.com/metadata/pkg/model"
+	"movieexample.com/pkg/outbox"
+)
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+	r := New()
+	for _, m := range []*model.Metadata{
+		{ID: "1", Title: "Star Wars", Director: "George Lucas", Genres: []string{"Sci-Fi"}},
+		{ID: "2", Title: "American Graffiti", Director: "George Lucas", Description: "Teenagers and their cars, star-crossed"},
+		{ID: "3", Title: "Stardust", Director: "Matthew Vaughn", Genres: []string{"Fantasy"}},
+	} {
+		assert.NoError(t, r.Put(ctx, m))
+	}
+
+	ids := func(res []model.SearchResult) []string {
+		var ids []string
+		for _, r := range res {
+			ids = append(ids, r.Metadata.ID)
+		}
+		return ids
+	}
+
+	res, err := r.Search(ctx, model.SearchQuery{Text: "star"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "3", "2"}, ids(res), "title match ranks above prefix and description matches")
+
+	res, _ = r.Search(ctx, model.SearchQuery{Text: "george st"})
+	assert.Equal(t, []string{"1", "2"}, ids(res))
+
+	res, _ = r.Search(ctx, model.SearchQuery{Text: "star", Genre: "fantasy"})
+	assert.Equal(t, []string{"3"}, ids(res))
+
+	res, _ = r.Search(ctx, model.SearchQuery{Director: "george lucas"})
+	assert.Equal(t, []string{"2", "1"}, ids(res))
+
+	assert.NoError(t, r.Delete(ctx, "3"))
+	assert.NoError(t, r.Put(ctx, &model.Metadata{ID: "1", Title: "Episode IV", Version: 1}))
+	res, _ = r.Search(ctx, model.SearchQuery{Text: "star"})
+	assert.Equal(t, []string{"2"}, ids(res), "index follows updates and deletes")
+}
+
+func TestVersions(t *testing.T) {
+	ctx := context.Background()
+	r := New()
+	m := &model.Metadata{ID: "1", Title: "Star Wars", Genres: []string{"Sci-Fi"}}
+	assert.NoError(t, r.Put(ctx, m))
+	assert.Equal(t, int64(1), m.Version)
+
+	m.Title = "changed by the caller"
+	m.Genres[0] = "Drama"
+	got, err := r.Get(ctx, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, &model.Metadata{ID: "1", Title: "Star Wars", Genres: []string{"Sci-Fi"}, Version: 1}, got, "the stored copy does not follow the caller's")
+
+	err = r.Update(ctx, &model.Metadata{ID: "1", Title: "Episode IV"})
+	assert.ErrorIs(t, err, repository.ErrVersionMismatch, "an update without a version is not applied")
+	err = r.Update(ctx, &model.Metadata{ID: "1", Title: "Episode IV", Version: 2})
+	assert.ErrorIs(t, err, repository.ErrVersionMismatch)
+	err = r.Put(ctx, &model.Metadata{ID: "1", Title: "Episode IV", Version: 2})
+	assert.ErrorIs(t, err, repository.ErrVersionMismatch)
+	err = r.Put(ctx, &model.Metadata{ID: "1", Title: "Episode IV"})
+	assert.ErrorIs(t, err, repository.ErrVersionMismatch, "a put without a version does not overwrite existing metadata")
+
+	u := &model.Metadata{ID: "1", Title: "Episode IV", Version: 1}
+	assert.NoError(t, r.Update(ctx, u))
+	assert.Equal(t, int64(2), u.Version)
+	got, _ = r.Get(ctx, "1")
+	assert.Equal(t, "Episode IV", got.Title)
+	got.Title = "changed by the reader"
+	list, _ := r.List(ctx, model.ListOptions{})
+	list[0].Genres = append(list[0].Genres, "Drama")
+	got, _ = r.Get(ctx, "1")
+	assert.Equal(t, &model.Metadata{ID: "1", Title: "Episode IV", Version: 2}, got, "reads return copies of the stored metadata")
+
+	err = r.Update(ctx, &model.Metadata{ID: "2", Version: 1})
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestOutbox(t *testing.T) {
+	ctx := context.Background()
+	r := New()
+	m := &model.Metadata{ID: "1", Title: "Star Wars"}
+	assert.NoError(t, r.Put(ctx, m))
+	assert.NoError(t, r.Update(ctx, &model.Metadata{ID: "1", Title: "Episode IV", Version: 1}))
+	assert.ErrorIs(t, r.Update(ctx, &model.Metadata{ID: "1", Title: "stale", Version: 1}), repository.ErrVersionMismatch)
+	assert.NoError(t, r.Delete(ctx, "1"))
+	assert.ErrorIs(t, r.Delete(ctx, "1"), repository.ErrNotFound)
+
+	events, err := r.Outbox().Pending(ctx, 10)
+	assert.NoError(t, err)
+	require.Len(t, events, 3)
+	var changes []model.MetadataChanged
+	for _, e := range events {
+		assert.Equal(t, outbox.TypeMetadataChanged, e.Type)
+		assert.Equal(t, "1", e.AggregateID)
+		var c model.MetadataChanged
+		require.NoError(t, json.Unmarshal(e.Payload, &c))
+		changes = append(changes, c)
+	}
+	assert.Equal(t, []model.MetadataChanged{
+		{ID: "1", Version: 1},
+		{ID: "1", Version: 2},
+		{ID: "1", Deleted: true},
+	}, changes)
+}
+
+func TestGetBatch(t *testing.T) {
+	ctx := context.Background()
+	r := New()
+	assert.NoError(t, r.Put(ctx, &model.Metadata{ID: "1", Title: "Star Wars"}))
+	assert.NoError(t, r.Put(ctx, &model.Metadata{ID: "2", Title: "Stardust"}))
+
+	res, err := r.GetBatch(ctx, []string{"2", "3", "1"})
+	assert.NoError(t, err)
+	require.Len(t, res, 2, "ids without metadata are absent")
+	assert.Equal(t, "Star Wars", res["1"].Title)
+	assert.Equal(t, "Stardust", res["2"].Title)
+
+	res, err = r.GetBatch(ctx, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+}
@@ -0,0 +1,121 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"movieexample.com/metadata/pkg/model"
+)
+
+// detailTables lists the tables holding the multi-valued movie fields.
+var detailTables = []string{"movie_genres", "movie_cast", "movie_crew", "movie_languages", "movie_external_ids"}
+
+// writeDetails replaces the multi-valued fields of a movie within a transaction.
+func writeDetails(ctx context.Context, tx *sql.Tx, m *model.Metadata) error {
+	for _, table := range detailTables {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM "+table+" WHERE movie_id = ?", m.ID); err != nil {
+			return err
+		}
+	}
+	for i, g := range m.Genres {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO movie_genres (movie_id, position, genre) VALUES (?, ?, ?)", m.ID, i, g); err != nil {
+			return err
+		}
+	}
+	for i, c := range m.Cast {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO movie_cast (movie_id, position, name, character_name) VALUES (?, ?, ?, ?)", m.ID, i, c.Name, c.Character); err != nil {
+			return err
+		}
+	}
+	for i, c := range m.Crew {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO movie_crew (movie_id, position, name, job) VALUES (?, ?, ?, ?)", m.ID, i, c.Name, c.Job); err != nil {
+			return err
+		}
+	}
+	for i, l := range m.SpokenLanguages {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO movie_languages (movie_id, position, language) VALUES (?, ?, ?)", m.ID, i, l); err != nil {
+			return err
+		}
+	}
+	for source, id := range m.ExternalIDs {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO movie_external_ids (movie_id, source, external_id) VALUES (?, ?, ?)", m.ID, source, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadDetails fills in the multi-valued fields of the given movies.
+func loadDetails(ctx context.Context, db *sql.DB, ms []*model.Metadata) error {
+	if len(ms) == 0 {
+		return nil
+	}
+	byID := make(map[string]*model.Metadata, len(ms))
+	args := make([]any, 0, len(ms))
+	for _, m := range ms {
+		byID[m.ID] = m
+		args = append(args, m.ID)
+	}
+	in := placeholders(len(ms))
+
+	if err := scanDetails(ctx, db, byID, "SELECT movie_id, genre FROM movie_genres WHERE movie_id IN "+in+" ORDER BY movie_id, position", args, func(m *model.Metadata, v []string) {
+		m.Genres = append(m.Genres, v[0])
+	}); err != nil {
+		return err
+	}
+	if err := scanDetails(ctx, db, byID, "SELECT movie_id, name, character_name FROM movie_cast WHERE movie_id IN "+in+" ORDER BY movie_id, position", args, func(m *model.Metadata, v []string) {
+		m.Cast = append(m.Cast, model.CastMember{Name: v[0], Character: v[1]})
+	}); err != nil {
+		return err
+	}
+	if err := scanDetails(ctx, db, byID, "SELECT movie_id, name, job FROM movie_crew WHERE movie_id IN "+in+" ORDER BY movie_id, position", args, func(m *model.Metadata, v []string) {
+		m.Crew = append(m.Crew, model.CrewMember{Name: v[0], Job: v[1]})
+	}); err != nil {
+		return err
+	}
+	if err := scanDetails(ctx, db, byID, "SELECT movie_id, language FROM movie_languages WHERE movie_id IN "+in+" ORDER BY movie_id, position", args, func(m *model.Metadata, v []string) {
+		m.SpokenLanguages = append(m.SpokenLanguages, v[0])
+	}); err != nil {
+		return err
+	}
+	return scanDetails(ctx, db, byID, "SELECT movie_id, source, external_id FROM movie_external_ids WHERE movie_id IN "+in, args, func(m *model.Metadata, v []string) {
+		if m.ExternalIDs == nil {
+			m.ExternalIDs = map[string]string{}
+		}
+		m.ExternalIDs[v[0]] = v[1]
+	})
+}
+
+// scanDetails runs a detail query whose first column is the movie id followed by string columns
+// and applies each row to the matching movie.
+func scanDetails(ctx context.Context, db *sql.DB, byID map[string]*model.Metadata, query string, args []any, apply func(*model.Metadata, []string)) error {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	n := len(columns) - 1
+	for rows.Next() {
+		var movieID string
+		values := make([]sql.NullString, n)
+		dest := []any{&movieID}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		v := make([]string, n)
+		for i := range values {
+			v[i] = values[i].String
+		}
+		if m, ok := byID[movieID]; ok {
+			apply(m, v)
+		}
+	}
+	return rows.Err()
+}
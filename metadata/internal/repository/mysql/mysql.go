@@ -3,10 +3,12 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"strings"
 
 	_ "github.com/go-sql-driver/mysql"
 	"movieexample.com/metadata/internal/repository"
 	"movieexample.com/metadata/pkg/model"
+	outboxmysql "movieexample.com/pkg/outbox/mysql"
 )
 
 type Repository struct {
@@ -21,27 +23,292 @@ func New() (*Repository, error) {
 	return &Repository{db}, nil
 }
 
+// outboxTable defines the table of the metadata change events.
+const outboxTable = "metadata_outbox"
+
+const movieColumns = "id, title, description, director, release_date, runtime_minutes, version"
+
 // Get retrieves movie metadata for by movie id.
 func (r *Repository) Get(ctx context.Context, id string) (*model.Metadata, error) {
-	var title, description, director string
-	row := r.db.QueryRowContext(ctx, "SELECT title, description, director FROM movies WHERE id = ?", id)
-	if err := row.Scan(&title, &description, &director); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, repository.ErrNotFound
-		}
+	res, err := r.query(ctx, "SELECT "+movieColumns+" FROM movies WHERE id = ?", id)
+	if err != nil {
 		return nil, err
 	}
-	return &model.Metadata{
-		ID:          id,
-		Title:       title,
-		Description: description,
-		Director:    director,
-	}, nil
+	if len(res) == 0 {
+		return nil, repository.ErrNotFound
+	}
+	return res[0], nil
+}
 
+// GetBatch retrieves movie metadata for the given movie ids keyed by id.
+// Ids without metadata are absent from the result.
+func (r *Repository) GetBatch(ctx context.Context, ids []string) (map[string]*model.Metadata, error) {
+	res := make(map[string]*model.Metadata, len(ids))
+	if len(ids) == 0 {
+		return res, nil
+	}
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	ms, err := r.query(ctx, "SELECT "+movieColumns+" FROM movies WHERE id IN "+placeholders(len(args)), args...)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range ms {
+		res[m.ID] = m
+	}
+	return res, nil
 }
 
-// Put adds movie metadata for a given movie id.
+// Put adds movie metadata for a given movie id or replaces it and bumps its version.
+// It returns ErrVersionMismatch unless m carries the stored version, zero for new metadata.
 func (r *Repository) Put(ctx context.Context, m *model.Metadata) error {
-	_, err := r.db.ExecContext(ctx, "INSERT INTO movies (id, title, description, director) VALUES (?, ?, ?, ?)", m.ID, m.Title, m.Description, m.Director)
-	return err
+	return r.write(ctx, m, false)
+}
+
+// Update replaces existing movie metadata and bumps its version.
+// It returns ErrNotFound if there is no metadata for the movie id and ErrVersionMismatch
+// unless m carries the stored version.
+func (r *Repository) Update(ctx context.Context, m *model.Metadata) error {
+	return r.write(ctx, m, true)
+}
+
+func (r *Repository) write(ctx context.Context, m *model.Metadata, mustExist bool) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	exists := true
+	var version int64
+	if err := tx.QueryRowContext(ctx, "SELECT version FROM movies WHERE id = ? FOR UPDATE", m.ID).Scan(&version); err == sql.ErrNoRows {
+		exists = false
+	} else if err != nil {
+		return err
+	}
+	if !exists && mustExist {
+		return repository.ErrNotFound
+	}
+	if m.Version != version {
+		return repository.ErrVersionMismatch
+	}
+	releaseDate := sql.NullString{String: m.ReleaseDate, Valid: m.ReleaseDate != ""}
+	if exists {
+		_, err = tx.ExecContext(ctx, "UPDATE movies SET title = ?, description = ?, director = ?, release_date = ?, runtime_minutes = ?, version = ? WHERE id = ?", m.Title, m.Description, m.Director, releaseDate, m.RuntimeMinutes, version+1, m.ID)
+	} else {
+		_, err = tx.ExecContext(ctx, "INSERT INTO movies ("+movieColumns+") VALUES (?, ?, ?, ?, ?, ?, ?)", m.ID, m.Title, m.Description, m.Director, releaseDate, m.RuntimeMinutes, version+1)
+	}
+	if err != nil {
+		return err
+	}
+	if err := writeDetails(ctx, tx, m); err != nil {
+		return err
+	}
+	e, err := repository.MetadataChangedEvent(m.ID, version+1, false)
+	if err != nil {
+		return err
+	}
+	if err := outboxmysql.Insert(ctx, tx, outboxTable, e); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	m.Version = version + 1
+	return nil
+}
+
+// Delete removes movie metadata by movie id.
+func (r *Repository) Delete(ctx context.Context, id string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	res, err := tx.ExecContext(ctx, "DELETE FROM movies WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return repository.ErrNotFound
+	}
+	e, err := repository.MetadataChangedEvent(id, 0, true)
+	if err != nil {
+		return err
+	}
+	if err := outboxmysql.Insert(ctx, tx, outboxTable, e); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Outbox returns the outbox of the metadata change events.
+func (r *Repository) Outbox() *outboxmysql.Store {
+	return outboxmysql.NewStore(r.db, outboxTable)
+}
+
+// List returns movie metadata sorted and paginated according to the given options.
+func (r *Repository) List(ctx context.Context, opts model.ListOptions) ([]*model.Metadata, error) {
+	column := "id"
+	if opts.OrderBy == model.OrderByTitle {
+		column = "title"
+	}
+	cmp, dir := ">", "ASC"
+	if opts.Descending {
+		cmp, dir = "<", "DESC"
+	}
+	query := "SELECT " + movieColumns + " FROM movies"
+	var args []any
+	if opts.After != nil {
+		if column == "id" {
+			query += " WHERE id " + cmp + " ?"
+			args = append(args, opts.After.ID)
+		} else {
+			query += " WHERE (" + column + ", id) " + cmp + " (?, ?)"
+			args = append(args, opts.After.Key, opts.After.ID)
+		}
+	}
+	query += " ORDER BY " + column + " " + dir
+	if column != "id" {
+		query += ", id " + dir
+	}
+	if opts.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, opts.Limit)
+	}
+	return r.query(ctx, query, args...)
+}
+
+// query runs a movies query selecting movieColumns and loads the details of the found movies.
+func (r *Repository) query(ctx context.Context, query string, args ...any) ([]*model.Metadata, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var res []*model.Metadata
+	for rows.Next() {
+		var m model.Metadata
+		var releaseDate sql.NullString
+		if err := rows.Scan(&m.ID, &m.Title, &m.Description, &m.Director, &releaseDate, &m.RuntimeMinutes, &m.Version); err != nil {
+			return nil, err
+		}
+		m.ReleaseDate = releaseDate.String
+		res = append(res, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := loadDetails(ctx, r.db, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// minTokenSize is the default innodb_ft_min_token_size. Shorter words are not indexed.
+const minTokenSize = 3
+
+// stopwords is the default InnoDB full-text stopword list. Stopwords are not indexed.
+var stopwords = map[string]bool{
+	"a": true, "about": true, "an": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"com": true, "de": true, "en": true, "for": true, "from": true, "how": true, "i": true, "in": true,
+	"is": true, "it": true, "la": true, "of": true, "on": true, "or": true, "that": true, "the": true,
+	"this": true, "to": true, "was": true, "what": true, "when": true, "where": true, "who": true,
+	"will": true, "with": true, "und": true, "www": true,
+}
+
+// booleanQuery returns the full-text boolean mode query of the search text. Every indexed word
+// is required and the last one is matched as a prefix. Words that are not indexed would never
+// match as required terms, so they are left out, apart from the last one, which is an optional
+// prefix because the caller may still be typing it.
+func booleanQuery(text string) string {
+	tokens := repository.Tokenize(text)
+	var terms []string
+	for i, t := range tokens {
+		indexed := len([]rune(t)) >= minTokenSize && !stopwords[t]
+		switch {
+		case i == len(tokens)-1 && indexed:
+			terms = append(terms, "+"+t+"*")
+		case i == len(tokens)-1:
+			terms = append(terms, t+"*")
+		case indexed:
+			terms = append(terms, "+"+t)
+		}
+	}
+	return strings.Join(terms, " ")
+}
+
+// Search returns movie metadata matching the query ordered by relevance.
+func (r *Repository) Search(ctx context.Context, q model.SearchQuery) ([]model.SearchResult, error) {
+	match := booleanQuery(q.Text)
+	query := "SELECT id, 0 FROM movies WHERE 1 = 1"
+	var args []any
+	if match != "" {
+		query = "SELECT id, MATCH (title, description, director) AGAINST (? IN BOOLEAN MODE) AS score FROM movies WHERE MATCH (title, description, director) AGAINST (? IN BOOLEAN MODE)"
+		args = append(args, match, match)
+	}
+	if q.Director != "" {
+		query += " AND director = ?"
+		args = append(args, q.Director)
+	}
+	if q.Genre != "" {
+		query += " AND EXISTS (SELECT 1 FROM movie_genres g WHERE g.movie_id = movies.id AND g.genre = ?)"
+		args = append(args, q.Genre)
+	}
+	if match != "" {
+		query += " ORDER BY score DESC, title, id"
+	} else {
+		query += " ORDER BY title, id"
+	}
+	if q.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, q.Limit)
+	}
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []any
+	scores := map[string]float64{}
+	for rows.Next() {
+		var id string
+		var score float64
+		if err := rows.Scan(&id, &score); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+		scores[id] = score
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	ms, err := r.query(ctx, "SELECT "+movieColumns+" FROM movies WHERE id IN "+placeholders(len(ids)), ids...)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*model.Metadata, len(ms))
+	for _, m := range ms {
+		byID[m.ID] = m
+	}
+	res := make([]model.SearchResult, 0, len(ids))
+	for _, id := range ids {
+		if m, ok := byID[id.(string)]; ok {
+			res = append(res, model.SearchResult{Metadata: m, Score: scores[m.ID]})
+		}
+	}
+	return res, nil
+}
+
+// placeholders returns a parenthesized list of n query placeholders.
+func placeholders(n int) string {
+	return "(" + strings.TrimSuffix(strings.Repeat("?, ", n), ", ") + ")"
 }
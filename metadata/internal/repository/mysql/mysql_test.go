@@ -0,0 +1,24 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBooleanQuery(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "Star Wars", want: "+star +wars*"},
+		{text: "george st", want: "+george st*"},
+		{text: "The Empire Strikes Back", want: "+empire +strikes +back*"},
+		{text: "what's up doc", want: "+doc*"},
+		{text: "Up", want: "up*"},
+		{text: "  ", want: ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, booleanQuery(tt.text), tt.text)
+	}
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"movieexample.com/metadata/pkg/model"
+	"movieexample.com/pkg/outbox"
+)
+
+// MetadataChangedEvent returns the outbox event of written movie metadata or, if deleted is set, of deleted metadata.
+func MetadataChangedEvent(id string, version int64, deleted bool) (outbox.Event, error) {
+	return outbox.NewEvent(outbox.TypeMetadataChanged, id, model.MetadataChanged{ID: id, Version: version, Deleted: deleted})
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tokenize splits text into lowercase words for full-text indexing and search.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
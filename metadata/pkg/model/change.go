@@ -0,0 +1,8 @@
+package model
+
+// MetadataChanged defines the payload of an event published when movie metadata is written or deleted.
+type MetadataChanged struct {
+	ID      string `json:"id"`
+	Version int64  `json:"version,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
@@ -0,0 +1,36 @@
+package model
+
+// OrderBy defines a field metadata listings can be sorted by.
+type OrderBy string
+
+// Supported listing sort fields.
+const (
+	OrderByID    = OrderBy("id")
+	OrderByTitle = OrderBy("title")
+)
+
+// Cursor defines a position in a sorted metadata listing.
+type Cursor struct {
+	OrderBy    OrderBy `json:"o"`
+	Descending bool    `json:"d,omitempty"`
+	Key        string  `json:"k"`
+	ID         string  `json:"id"`
+}
+
+// CursorOf returns the cursor pointing right after the given record in a listing
+// sorted by orderBy in the given direction.
+func CursorOf(m *Metadata, orderBy OrderBy, descending bool) *Cursor {
+	c := &Cursor{OrderBy: orderBy, Descending: descending, Key: m.ID, ID: m.ID}
+	if orderBy == OrderByTitle {
+		c.Key = m.Title
+	}
+	return c
+}
+
+// ListOptions defines the parameters of a metadata listing.
+type ListOptions struct {
+	Limit      int
+	After      *Cursor
+	OrderBy    OrderBy
+	Descending bool
+}
@@ -6,20 +6,46 @@ import "movieexample.com/gen"
 
 // MetadataToProto converts a Metadata struct into a generated proto counterpart.
 func MetadataToProto(m *Metadata) *gen.Metadata {
-	return &gen.Metadata{
-		Id:          m.ID,
-		Title:       m.Title,
-		Description: m.Description,
-		Director:    m.Director,
+	res := &gen.Metadata{
+		Id:              m.ID,
+		Title:           m.Title,
+		Description:     m.Description,
+		Director:        m.Director,
+		Version:         m.Version,
+		Genres:          m.Genres,
+		ReleaseDate:     m.ReleaseDate,
+		RuntimeMinutes:  int32(m.RuntimeMinutes),
+		SpokenLanguages: m.SpokenLanguages,
+		ExternalIds:     m.ExternalIDs,
 	}
+	for _, c := range m.Cast {
+		res.Cast = append(res.Cast, &gen.CastMember{Name: c.Name, Character: c.Character})
+	}
+	for _, c := range m.Crew {
+		res.Crew = append(res.Crew, &gen.CrewMember{Name: c.Name, Job: c.Job})
+	}
+	return res
 }
 
 // MetadataFromProto converts a generated proto counterpart into a Metadata struct.
 func MetadataFromProto(m *gen.Metadata) *Metadata {
-	return &Metadata{
-		ID:          m.Id,
-		Title:       m.Title,
-		Description: m.Description,
-		Director:    m.Director,
+	res := &Metadata{
+		ID:              m.Id,
+		Title:           m.Title,
+		Description:     m.Description,
+		Director:        m.Director,
+		Version:         m.Version,
+		Genres:          m.Genres,
+		ReleaseDate:     m.ReleaseDate,
+		RuntimeMinutes:  int(m.RuntimeMinutes),
+		SpokenLanguages: m.SpokenLanguages,
+		ExternalIDs:     m.ExternalIds,
+	}
+	for _, c := range m.Cast {
+		res.Cast = append(res.Cast, CastMember{Name: c.Name, Character: c.Character})
 	}
-}
\ No newline at end of file
+	for _, c := range m.Crew {
+		res.Crew = append(res.Crew, CrewMember{Name: c.Name, Job: c.Job})
+	}
+	return res
+}
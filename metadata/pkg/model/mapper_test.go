@@ -0,0 +1,26 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadataProtoRoundTrip(t *testing.T) {
+	m := &Metadata{
+		ID:              "1",
+		Title:           "Star Wars",
+		Description:     "A long time ago",
+		Director:        "George Lucas",
+		Version:         3,
+		Genres:          []string{"Sci-Fi", "Adventure"},
+		ReleaseDate:     "1977-05-25",
+		RuntimeMinutes:  121,
+		Cast:            []CastMember{{Name: "Mark Hamill", Character: "Luke Skywalker"}},
+		Crew:            []CrewMember{{Name: "John Williams", Job: "Composer"}},
+		SpokenLanguages: []string{"en"},
+		ExternalIDs:     map[string]string{ExternalSourceIMDb: "tt0076759"},
+	}
+	assert.Equal(t, m, MetadataFromProto(MetadataToProto(m)))
+	assert.Equal(t, &Metadata{ID: "2"}, MetadataFromProto(MetadataToProto(&Metadata{ID: "2"})), "empty details stay empty")
+}
@@ -7,4 +7,37 @@ type Metadata struct{
 	Title string `json:"title"`
 	Description string `json:"description"`
 	Director string `json:"director"`
-}
\ No newline at end of file
+	// Version is bumped on every write. The version on a write is the
+	// version the writer expects the stored record to have, zero if there is none.
+	Version int64    `json:"version"`
+	Genres  []string `json:"genres,omitempty"`
+	// ReleaseDate is the release date in YYYY-MM-DD form.
+	ReleaseDate     string       `json:"release_date,omitempty"`
+	RuntimeMinutes  int          `json:"runtime_minutes,omitempty"`
+	Cast            []CastMember `json:"cast,omitempty"`
+	Crew            []CrewMember `json:"crew,omitempty"`
+	SpokenLanguages []string     `json:"spoken_languages,omitempty"`
+	// ExternalIDs maps an external source such as ExternalSourceIMDb to the movie id in it.
+	ExternalIDs map[string]string `json:"external_ids,omitempty"`
+}
+
+// CastMember defines an actor starring in a movie.
+type CastMember struct {
+	Name      string `json:"name"`
+	Character string `json:"character,omitempty"`
+}
+
+// CrewMember defines a person working on a movie behind the camera.
+type CrewMember struct {
+	Name string `json:"name"`
+	Job  string `json:"job,omitempty"`
+}
+
+// Known external id sources.
+const (
+	ExternalSourceIMDb = "imdb"
+	ExternalSourceTMDb = "tmdb"
+)
+
+// ReleaseDateLayout is the time layout of Metadata.ReleaseDate.
+const ReleaseDateLayout = "2006-01-02"
@@ -0,0 +1,18 @@
+package model
+
+// SearchQuery defines a full-text search over movie metadata.
+type SearchQuery struct {
+	// Text is matched against the title, the description and the director.
+	// The last word of the text also matches as a prefix.
+	Text string
+	// Director and Genre filter the results by exact, case-insensitive match.
+	Director string
+	Genre    string
+	Limit    int
+}
+
+// SearchResult defines a movie matching a search query and its relevance score.
+type SearchResult struct {
+	Metadata *Metadata `json:"metadata"`
+	Score    float64   `json:"score"`
+}
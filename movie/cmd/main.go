@@ -78,8 +78,16 @@ func main() {
 	setJaegerAsProvider(ctx, cfg)
 
 	log.Printf("Starting %s on port %s", serviceName, port)
-	metadataGateway := metadatagateway.New(registry)
-	ratingGateway := ratinggateway.New(registry)
+	metadataGateway, err := metadatagateway.New(registry)
+	if err != nil {
+		panic(err)
+	}
+	defer metadataGateway.Close()
+	ratingGateway, err := ratinggateway.New(registry)
+	if err != nil {
+		panic(err)
+	}
+	defer ratingGateway.Close()
 	ctrl := movie.New(ratingGateway, metadataGateway)
 	h := grpchandler.New(ctrl)
 	lis, err := net.Listen("tcp", fmt.Sprintf("localhost:%s", port))
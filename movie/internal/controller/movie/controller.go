@@ -3,6 +3,10 @@ package movie
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
 
 	metadatamodel "movieexample.com/metadata/pkg/model"
 	"movieexample.com/movie/internal/gateway"
@@ -12,45 +16,213 @@ import (
 
 var ErrNotFound = errors.New("movie metadata not found")
 
+// ErrInvalidArgument is returned when a request contains malformed parameters.
+var ErrInvalidArgument = errors.New("invalid argument")
+
+// MaxBatchSize is the maximum number of movies in a batch request.
+const MaxBatchSize = 100
+
 type ratingGateway interface {
 	GetAggregatedRating(ctx context.Context, recordID ratingmodel.RecordID, recordType ratingmodel.RecordType) (float64, error)
-	//PutRating(ctx context.Context, recordID ratingmodel.RecordID, recordType ratingmodel.RecordType, rating *ratingmodel.Rating) error
+	GetRatingStats(ctx context.Context, recordID ratingmodel.RecordID, recordType ratingmodel.RecordType) (*ratingmodel.RatingStats, error)
+	BatchGetAggregatedRating(ctx context.Context, recordIDs []ratingmodel.RecordID, recordType ratingmodel.RecordType) (map[ratingmodel.RecordID]float64, map[ratingmodel.RecordID]error, error)
+	PutRating(ctx context.Context, recordID ratingmodel.RecordID, recordType ratingmodel.RecordType, rating *ratingmodel.Rating) error
 }
 
 type metadataGateway interface {
 	Get(ctx context.Context, id string) (*metadatamodel.Metadata, error)
+	BatchGet(ctx context.Context, ids []string) (map[string]*metadatamodel.Metadata, map[string]error, error)
 }
 
+// DefaultTimeout defines the shared deadline of the gateway calls of a request.
+const DefaultTimeout = 3 * time.Second
+
 // Controller defines a movie service controller.
 type Controller struct {
 	ratingGateway   ratingGateway
 	metadataGateway metadataGateway
+	timeout         time.Duration
+}
+
+// Option configures a movie service controller.
+type Option func(*Controller)
+
+// WithTimeout sets the shared deadline of the gateway calls of a request.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Controller) {
+		c.timeout = d
+	}
 }
 
 // New creates a new movie service controller.
-func New(ratingGateway ratingGateway, metadataGateway metadataGateway) *Controller {
-	return &Controller{ratingGateway: ratingGateway, metadataGateway: metadataGateway}
+func New(ratingGateway ratingGateway, metadataGateway metadataGateway, opts ...Option) *Controller {
+	c := &Controller{ratingGateway: ratingGateway, metadataGateway: metadataGateway, timeout: DefaultTimeout}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // Get returns the movie details including the aggregated rating and movie metadata.
-func (c *Controller) Get(ctx context.Context, id string) (*model.MovieDetails, error) {
-	metadata, err := c.metadataGateway.Get(ctx, id)
-	if err != nil && errors.Is(err, gateway.ErrNotFound) {
+// The rating distribution is included if withStats is set. Metadata and ratings are
+// fetched concurrently. The rating is nil if the movie has no ratings yet. A failure to
+// fetch the rating or its distribution does not fail the request, the part is reported
+// as degraded instead.
+func (c *Controller) Get(ctx context.Context, id string, withStats bool) (*model.MovieDetails, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var metadata *metadatamodel.Metadata
+	var metadataErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		metadata, metadataErr = c.metadataGateway.Get(ctx, id)
+		if metadataErr != nil {
+			// The ratings of a movie without metadata are not needed.
+			cancel()
+		}
+	}()
+	var rating float64
+	var ratingErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		rating, ratingErr = c.ratingGateway.GetAggregatedRating(ctx, ratingmodel.RecordID(id), ratingmodel.RecordTypeMovie)
+	}()
+	var stats *ratingmodel.RatingStats
+	var statsErr error
+	if withStats {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stats, statsErr = c.ratingGateway.GetRatingStats(ctx, ratingmodel.RecordID(id), ratingmodel.RecordTypeMovie)
+		}()
+	}
+	wg.Wait()
+
+	if metadataErr != nil && errors.Is(metadataErr, gateway.ErrNotFound) {
 		return nil, ErrNotFound
+	} else if metadataErr != nil {
+		return nil, metadataErr
 	}
-	if err != nil {
-		return nil, err
+	details := &model.MovieDetails{Metadata: *metadata}
+	if ratingErr != nil && !errors.Is(ratingErr, gateway.ErrNotFound) {
+		log.Printf("Rating of movie %s is degraded: %v\n", id, ratingErr)
+		details.DegradedParts = append(details.DegradedParts, model.PartRating)
+	} else if ratingErr == nil {
+		details.Rating = &rating
 	}
-	rating, err := c.ratingGateway.GetAggregatedRating(ctx, ratingmodel.RecordID(id), ratingmodel.RecordTypeMovie)
-	if err != nil && errors.Is(err, gateway.ErrNotFound) {
-		return nil, ErrNotFound
+	if statsErr != nil && !errors.Is(statsErr, gateway.ErrNotFound) {
+		log.Printf("Rating stats of movie %s are degraded: %v\n", id, statsErr)
+		details.DegradedParts = append(details.DegradedParts, model.PartRatingStats)
+	} else if statsErr == nil {
+		details.RatingStats = stats
 	}
-	if err != nil {
-		return nil, err
+	return details, nil
+}
+
+// Rate writes the rating of a movie by a user. It returns ErrNotFound if the movie has
+// no metadata and ErrInvalidArgument if the rating service rejects the rating value.
+func (c *Controller) Rate(ctx context.Context, id string, userID ratingmodel.UserID, value ratingmodel.RatingValue) error {
+	if id == "" || userID == "" {
+		return fmt.Errorf("%w: empty movie id or user id", ErrInvalidArgument)
 	}
-	return &model.MovieDetails{
-		Rating:   &rating,
-		Metadata: *metadata,
-	}, nil
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
 
+	if _, err := c.metadataGateway.Get(ctx, id); err != nil && errors.Is(err, gateway.ErrNotFound) {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+	err := c.ratingGateway.PutRating(ctx, ratingmodel.RecordID(id), ratingmodel.RecordTypeMovie, &ratingmodel.Rating{UserID: userID, Value: value})
+	if err != nil && errors.Is(err, gateway.ErrInvalidArgument) {
+		return fmt.Errorf("%w: %v", ErrInvalidArgument, err)
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// BatchResult holds the details of a single movie of a batch request or the reason they are missing.
+type BatchResult struct {
+	ID      string
+	Details *model.MovieDetails
+	Err     error
+}
+
+// BatchGet returns the details of several movies in request order, fetching metadata and
+// ratings of all movies with one call to each service. Both calls run concurrently under
+// the shared deadline. A movie without metadata gets ErrNotFound as its result error and a
+// movie whose metadata fails otherwise gets that error, instead of failing the batch.
+// A failure to fetch the ratings marks the rating of every movie as degraded, a failure
+// to fetch the rating of a single movie only marks that one.
+func (c *Controller) BatchGet(ctx context.Context, ids []string) ([]BatchResult, error) {
+	if len(ids) > MaxBatchSize {
+		return nil, fmt.Errorf("%w: more than %d movies", ErrInvalidArgument, MaxBatchSize)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var metadata map[string]*metadatamodel.Metadata
+	var metadataFailed map[string]error
+	var metadataErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		metadata, metadataFailed, metadataErr = c.metadataGateway.BatchGet(ctx, ids)
+		if metadataErr != nil {
+			cancel()
+		}
+	}()
+	var ratings map[ratingmodel.RecordID]float64
+	var ratingsFailed map[ratingmodel.RecordID]error
+	var ratingErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		recordIDs := make([]ratingmodel.RecordID, len(ids))
+		for i, id := range ids {
+			recordIDs[i] = ratingmodel.RecordID(id)
+		}
+		ratings, ratingsFailed, ratingErr = c.ratingGateway.BatchGetAggregatedRating(ctx, recordIDs, ratingmodel.RecordTypeMovie)
+	}()
+	wg.Wait()
+
+	if metadataErr != nil {
+		return nil, metadataErr
+	}
+	if ratingErr != nil {
+		log.Printf("Ratings of %d movies are degraded: %v\n", len(ids), ratingErr)
+	}
+	res := make([]BatchResult, len(ids))
+	for i, id := range ids {
+		res[i].ID = id
+		if err, ok := metadataFailed[id]; ok {
+			res[i].Err = err
+			continue
+		}
+		m, ok := metadata[id]
+		if !ok {
+			res[i].Err = ErrNotFound
+			continue
+		}
+		details := &model.MovieDetails{Metadata: *m}
+		if err, ok := ratingsFailed[ratingmodel.RecordID(id)]; ok {
+			log.Printf("Rating of movie %s is degraded: %v\n", id, err)
+			details.DegradedParts = append(details.DegradedParts, model.PartRating)
+		} else if ratingErr != nil {
+			details.DegradedParts = append(details.DegradedParts, model.PartRating)
+		} else if rating, ok := ratings[ratingmodel.RecordID(id)]; ok {
+			details.Rating = &rating
+		}
+		res[i].Details = details
+	}
+	return res, nil
 }
@@ -0,0 +1,272 @@
+package movie
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"movieexample.com/gen"
+	metadatamodel "movieexample.com/metadata/pkg/model"
+	"movieexample.com/movie/internal/gateway"
+	ratinggateway "movieexample.com/movie/internal/gateway/rating/grpc"
+	"movieexample.com/movie/pkg/model"
+	"movieexample.com/pkg/discovery/memory"
+	ratingmodel "movieexample.com/rating/pkg/model"
+)
+
+type stubRatingGateway struct {
+	rating   float64
+	err      error
+	failing  ratingmodel.RecordID
+	statsErr error
+	putErr   error
+	delay    time.Duration
+	put      *ratingmodel.Rating
+}
+
+func (g *stubRatingGateway) GetAggregatedRating(ctx context.Context, recordID ratingmodel.RecordID, recordType ratingmodel.RecordType) (float64, error) {
+	select {
+	case <-time.After(g.delay):
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+	return g.rating, g.err
+}
+
+func (g *stubRatingGateway) GetRatingStats(ctx context.Context, recordID ratingmodel.RecordID, recordType ratingmodel.RecordType) (*ratingmodel.RatingStats, error) {
+	if g.statsErr != nil {
+		return nil, g.statsErr
+	}
+	return &ratingmodel.RatingStats{Count: 1}, nil
+}
+
+func (g *stubRatingGateway) BatchGetAggregatedRating(ctx context.Context, recordIDs []ratingmodel.RecordID, recordType ratingmodel.RecordType) (map[ratingmodel.RecordID]float64, map[ratingmodel.RecordID]error, error) {
+	if g.err != nil {
+		return nil, nil, g.err
+	}
+	res := map[ratingmodel.RecordID]float64{}
+	failed := map[ratingmodel.RecordID]error{}
+	for _, id := range recordIDs {
+		if id == g.failing {
+			failed[id] = errors.New("unavailable")
+		} else {
+			res[id] = g.rating
+		}
+	}
+	return res, failed, nil
+}
+
+func (g *stubRatingGateway) PutRating(ctx context.Context, recordID ratingmodel.RecordID, recordType ratingmodel.RecordType, rating *ratingmodel.Rating) error {
+	if g.putErr != nil {
+		return g.putErr
+	}
+	g.put = rating
+	return nil
+}
+
+type stubMetadataGateway struct {
+	err     error
+	missing string
+	failing string
+}
+
+func (g *stubMetadataGateway) Get(ctx context.Context, id string) (*metadatamodel.Metadata, error) {
+	if g.err != nil {
+		return nil, g.err
+	}
+	return &metadatamodel.Metadata{ID: id}, nil
+}
+
+func TestControllerGet(t *testing.T) {
+	rating := 4.5
+	tests := []struct {
+		name     string
+		rating   *stubRatingGateway
+		metadata *stubMetadataGateway
+		want     *model.MovieDetails
+		wantErr  error
+	}{
+		{
+			name:     "success",
+			rating:   &stubRatingGateway{rating: rating},
+			metadata: &stubMetadataGateway{},
+			want:     &model.MovieDetails{Rating: &rating, RatingStats: &ratingmodel.RatingStats{Count: 1}, Metadata: metadatamodel.Metadata{ID: "id"}},
+		},
+		{
+			name:     "metadata not found",
+			rating:   &stubRatingGateway{rating: rating},
+			metadata: &stubMetadataGateway{err: gateway.ErrNotFound},
+			wantErr:  ErrNotFound,
+		},
+		{
+			name:     "no ratings yet",
+			rating:   &stubRatingGateway{err: gateway.ErrNotFound, statsErr: gateway.ErrNotFound},
+			metadata: &stubMetadataGateway{},
+			want:     &model.MovieDetails{Metadata: metadatamodel.Metadata{ID: "id"}},
+		},
+		{
+			name:     "rating degraded",
+			rating:   &stubRatingGateway{delay: time.Second, statsErr: errors.New("unavailable")},
+			metadata: &stubMetadataGateway{},
+			want:     &model.MovieDetails{Metadata: metadatamodel.Metadata{ID: "id"}, DegradedParts: []string{model.PartRating, model.PartRatingStats}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(tt.rating, tt.metadata, WithTimeout(50*time.Millisecond))
+			got, err := c.Get(context.Background(), "id", true)
+			assert.Equal(t, tt.want, got)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+// failingRatingServer fails the rating reads with a gRPC status code.
+type failingRatingServer struct {
+	gen.UnimplementedRatingServiceServer
+	code codes.Code
+}
+
+func (s *failingRatingServer) GetAggregatedRating(ctx context.Context, req *gen.GetAggregatedRatingRequest) (*gen.GetAggregatedRatingResponse, error) {
+	return nil, status.Errorf(s.code, "rating %s", req.Id)
+}
+
+func (s *failingRatingServer) GetRatingStats(ctx context.Context, req *gen.GetRatingStatsRequest) (*gen.GetRatingStatsResponse, error) {
+	return nil, status.Errorf(s.code, "rating stats %s", req.Id)
+}
+
+// BatchGetAggregatedRating rates the first id, has no ratings for the second and fails the others with the status code.
+func (s *failingRatingServer) BatchGetAggregatedRating(ctx context.Context, req *gen.BatchGetAggregatedRatingRequest) (*gen.BatchGetAggregatedRatingResponse, error) {
+	resp := &gen.BatchGetAggregatedRatingResponse{}
+	for i, id := range req.Ids {
+		r := &gen.AggregatedRatingResult{Id: id}
+		switch i {
+		case 0:
+			r.Rating = 4
+		case 1:
+			r.Error = &gen.ItemError{Code: int32(codes.NotFound), Message: "no ratings"}
+		default:
+			r.Error = &gen.ItemError{Code: int32(s.code), Message: "failed"}
+		}
+		resp.Results = append(resp.Results, r)
+	}
+	return resp, nil
+}
+
+// serveRating serves a rating server and returns a gRPC gateway to it.
+func serveRating(t *testing.T, s gen.RatingServiceServer) *ratinggateway.Gateway {
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	gen.RegisterRatingServiceServer(srv, s)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	registry := memory.NewRegistry()
+	require.NoError(t, registry.Register(context.Background(), "rating-1", "rating", lis.Addr().String()))
+	g, err := ratinggateway.New(registry)
+	require.NoError(t, err)
+	t.Cleanup(func() { g.Close() })
+	return g
+}
+
+func TestControllerBatchGetThroughRatingGateway(t *testing.T) {
+	g := serveRating(t, &failingRatingServer{code: codes.Internal})
+	got, err := New(g, &stubMetadataGateway{}).BatchGet(context.Background(), []string{"1", "2", "3"})
+	assert.NoError(t, err)
+	rating := 4.0
+	assert.Equal(t, []BatchResult{
+		{ID: "1", Details: &model.MovieDetails{Rating: &rating, Metadata: metadatamodel.Metadata{ID: "1"}}},
+		{ID: "2", Details: &model.MovieDetails{Metadata: metadatamodel.Metadata{ID: "2"}}},
+		{ID: "3", Details: &model.MovieDetails{Metadata: metadatamodel.Metadata{ID: "3"}, DegradedParts: []string{model.PartRating}}},
+	}, got)
+}
+
+func TestControllerGetThroughRatingGateway(t *testing.T) {
+	tests := []struct {
+		code codes.Code
+		want *model.MovieDetails
+	}{
+		{code: codes.NotFound, want: &model.MovieDetails{Metadata: metadatamodel.Metadata{ID: "id"}}},
+		{code: codes.Unavailable, want: &model.MovieDetails{Metadata: metadatamodel.Metadata{ID: "id"}, DegradedParts: []string{model.PartRating, model.PartRatingStats}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			g := serveRating(t, &failingRatingServer{code: tt.code})
+			got, err := New(g, &stubMetadataGateway{}).Get(context.Background(), "id", true)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func (g *stubMetadataGateway) BatchGet(ctx context.Context, ids []string) (map[string]*metadatamodel.Metadata, map[string]error, error) {
+	if g.err != nil {
+		return nil, nil, g.err
+	}
+	res := map[string]*metadatamodel.Metadata{}
+	failed := map[string]error{}
+	for _, id := range ids {
+		if id == g.failing {
+			failed[id] = errMetadataUnavailable
+		} else if id != g.missing {
+			res[id] = &metadatamodel.Metadata{ID: id}
+		}
+	}
+	return res, failed, nil
+}
+
+var errMetadataUnavailable = errors.New("metadata unavailable")
+
+func TestControllerBatchGet(t *testing.T) {
+	rating := 4.5
+	c := New(&stubRatingGateway{rating: rating}, &stubMetadataGateway{missing: "2"})
+	got, err := c.BatchGet(context.Background(), []string{"1", "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []BatchResult{
+		{ID: "1", Details: &model.MovieDetails{Rating: &rating, Metadata: metadatamodel.Metadata{ID: "1"}}},
+		{ID: "2", Err: ErrNotFound},
+	}, got)
+
+	c = New(&stubRatingGateway{err: errors.New("unavailable")}, &stubMetadataGateway{})
+	got, err = c.BatchGet(context.Background(), []string{"1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []BatchResult{
+		{ID: "1", Details: &model.MovieDetails{Metadata: metadatamodel.Metadata{ID: "1"}, DegradedParts: []string{model.PartRating}}},
+	}, got)
+
+	c = New(&stubRatingGateway{rating: rating, failing: "2"}, &stubMetadataGateway{failing: "3"})
+	got, err = c.BatchGet(context.Background(), []string{"1", "2", "3"})
+	assert.NoError(t, err, "item failures do not fail the batch")
+	assert.Equal(t, []BatchResult{
+		{ID: "1", Details: &model.MovieDetails{Rating: &rating, Metadata: metadatamodel.Metadata{ID: "1"}}},
+		{ID: "2", Details: &model.MovieDetails{Metadata: metadatamodel.Metadata{ID: "2"}, DegradedParts: []string{model.PartRating}}},
+		{ID: "3", Err: errMetadataUnavailable},
+	}, got)
+
+	_, err = c.BatchGet(context.Background(), make([]string, MaxBatchSize+1))
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestControllerRate(t *testing.T) {
+	ctx := context.Background()
+	r := &stubRatingGateway{}
+	err := New(r, &stubMetadataGateway{}).Rate(ctx, "id", "user", 4)
+	assert.NoError(t, err)
+	assert.Equal(t, &ratingmodel.Rating{UserID: "user", Value: 4}, r.put)
+
+	r = &stubRatingGateway{}
+	err = New(r, &stubMetadataGateway{err: gateway.ErrNotFound}).Rate(ctx, "id", "user", 4)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Nil(t, r.put, "ratings of unknown movies are not written")
+
+	err = New(&stubRatingGateway{putErr: gateway.ErrInvalidArgument}, &stubMetadataGateway{}).Rate(ctx, "id", "user", 9)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
@@ -3,39 +3,51 @@ package grpc
 import (
 	"context"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"movieexample.com/gen"
 	"movieexample.com/internal/grpcutil"
 	"movieexample.com/metadata/pkg/model"
+	"movieexample.com/movie/internal/gateway"
 	"movieexample.com/pkg/discovery"
 )
 
+// Gateway defines a gRPC gateway for a movie metadata service.
 type Gateway struct {
-	registry discovery.Registry
+	conn *grpc.ClientConn
 }
 
-func New(r discovery.Registry) *Gateway {
-	return &Gateway{
-		registry: r,
+// New creates a new gRPC gateway for a movie metadata service.
+// All calls share one connection balanced across the service instances found in the registry.
+func New(r discovery.Registry) (*Gateway, error) {
+	conn, err := grpcutil.ServiceConnection("metadata", r)
+	if err != nil {
+		return nil, err
 	}
+	return &Gateway{conn: conn}, nil
+}
+
+// Close closes the connection of the gateway.
+func (g *Gateway) Close() error {
+	return g.conn.Close()
 }
 
 func (g *Gateway) Get(ctx context.Context, id string) (*model.Metadata, error) {
-	conn, err := grpcutil.ServiceConnection(ctx, "metadata", g.registry)
-	if err != nil {
-		return nil, err
-	}
-	defer conn.Close()
-	client := gen.NewMetadataServiceClient(conn)
+	client := gen.NewMetadataServiceClient(g.conn)
 
 	const maxRetries = 5
+	var err error
 	for i := 0; i < maxRetries; i++ {
-		resp, err := client.GetMetadata(ctx, &gen.GetMetadataRequest{Id: id}, nil)
+		var resp *gen.GetMetadataResponse
+		resp, err = client.GetMetadata(ctx, &gen.GetMetadataRequest{Id: id}, nil)
 		if err != nil {
 			if shouldRetry(err) {
 				continue
 			}
+			if status.Code(err) == codes.NotFound {
+				return nil, gateway.ErrNotFound
+			}
 			return nil, err
 		}
 		return model.MetadataFromProto(resp.Metadata), nil
@@ -43,6 +55,42 @@ func (g *Gateway) Get(ctx context.Context, id string) (*model.Metadata, error) {
 	return nil, err
 
 }
+
+// BatchGet gets movie metadata by movie ids keyed by id.
+// Ids without metadata are absent from the result, ids that fail otherwise get their error keyed by id.
+func (g *Gateway) BatchGet(ctx context.Context, ids []string) (map[string]*model.Metadata, map[string]error, error) {
+	client := gen.NewMetadataServiceClient(g.conn)
+
+	const maxRetries = 5
+	var err error
+	for i := 0; i < maxRetries; i++ {
+		var resp *gen.BatchGetMetadataResponse
+		resp, err = client.BatchGetMetadata(ctx, &gen.BatchGetMetadataRequest{Ids: ids})
+		if err != nil {
+			if shouldRetry(err) {
+				continue
+			}
+			return nil, nil, err
+		}
+		res := make(map[string]*model.Metadata, len(resp.Results))
+		var failed map[string]error
+		for _, r := range resp.Results {
+			if r.Error != nil && codes.Code(r.Error.Code) == codes.NotFound {
+				continue
+			} else if r.Error != nil {
+				if failed == nil {
+					failed = map[string]error{}
+				}
+				failed[r.Id] = status.Error(codes.Code(r.Error.Code), r.Error.Message)
+				continue
+			}
+			res[r.Id] = model.MetadataFromProto(r.Metadata)
+		}
+		return res, failed, nil
+	}
+	return nil, nil, err
+}
+
 func shouldRetry(err error) bool {
 	e, ok := status.FromError(err)
 	if !ok {
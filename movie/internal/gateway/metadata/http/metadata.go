@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
@@ -59,3 +60,24 @@ func (g *Gateway) Get(ctx context.Context, id string) (*model.Metadata, error) {
 	return metadata, nil
 
 }
+
+// BatchGet gets movie metadata by movie ids keyed by id, one request per id.
+// Ids without metadata are absent from the result, ids that fail otherwise get their error keyed by id.
+func (g *Gateway) BatchGet(ctx context.Context, ids []string) (map[string]*model.Metadata, map[string]error, error) {
+	res := make(map[string]*model.Metadata, len(ids))
+	var failed map[string]error
+	for _, id := range ids {
+		m, err := g.Get(ctx, id)
+		if err != nil && errors.Is(err, gateway.ErrNotFound) {
+			continue
+		} else if err != nil {
+			if failed == nil {
+				failed = map[string]error{}
+			}
+			failed[id] = err
+			continue
+		}
+		res[id] = m
+	}
+	return res, failed, nil
+}
@@ -2,40 +2,107 @@ package grpc
 
 import (
 	"context"
+	"fmt"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"movieexample.com/gen"
 	"movieexample.com/internal/grpcutil"
+	"movieexample.com/movie/internal/gateway"
 	"movieexample.com/pkg/discovery"
 	"movieexample.com/rating/pkg/model"
 )
 
+// Gateway defines a gRPC gateway for a rating service.
 type Gateway struct {
-	registry discovery.Registry
+	conn *grpc.ClientConn
 }
 
 // New creates a new gRPC gateway for a rating service.
-func New(r discovery.Registry) *Gateway {
-	return &Gateway{
-		registry: r,
+// All calls share one connection balanced across the service instances found in the registry.
+func New(r discovery.Registry) (*Gateway, error) {
+	conn, err := grpcutil.ServiceConnection("rating", r)
+	if err != nil {
+		return nil, err
 	}
+	return &Gateway{conn: conn}, nil
+}
+
+// Close closes the connection of the gateway.
+func (g *Gateway) Close() error {
+	return g.conn.Close()
 }
 
 // GetAggregatedRating returns the aggregated rating for a record or ErrNotFound if there are no ratings for it.
 func (g *Gateway) GetAggregatedRating(ctx context.Context, recordID model.RecordID, recordType model.RecordType) (float64, error) {
-	conn, err := grpcutil.ServiceConnection(ctx, "rating", g.registry)
-	if err != nil {
-		return 0, err
-	}
-	defer conn.Close()
-
-	client := gen.NewRatingServiceClient(conn)
+	client := gen.NewRatingServiceClient(g.conn)
 	resp, err := client.GetAggregatedRating(ctx, &gen.
 		GetAggregatedRatingRequest{Id: string(recordID),
 		Type: string(recordType)})
-	if err != nil {
+	if err != nil && status.Code(err) == codes.NotFound {
+		return 0, gateway.ErrNotFound
+	} else if err != nil {
 		return 0, err
 	}
 	return resp.Rating, nil
 }
 
+// BatchGetAggregatedRating returns the aggregated ratings of records of a type keyed by record id.
+// Records without ratings are absent from the result, records that fail otherwise get their error
+// keyed by record id.
+func (g *Gateway) BatchGetAggregatedRating(ctx context.Context, recordIDs []model.RecordID, recordType model.RecordType) (map[model.RecordID]float64, map[model.RecordID]error, error) {
+	client := gen.NewRatingServiceClient(g.conn)
+	req := &gen.BatchGetAggregatedRatingRequest{Type: string(recordType)}
+	for _, id := range recordIDs {
+		req.Ids = append(req.Ids, string(id))
+	}
+	resp, err := client.BatchGetAggregatedRating(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	res := make(map[model.RecordID]float64, len(resp.Results))
+	var failed map[model.RecordID]error
+	for _, r := range resp.Results {
+		if r.Error != nil && codes.Code(r.Error.Code) == codes.NotFound {
+			continue
+		} else if r.Error != nil {
+			if failed == nil {
+				failed = map[model.RecordID]error{}
+			}
+			failed[model.RecordID(r.Id)] = status.Error(codes.Code(r.Error.Code), r.Error.Message)
+			continue
+		}
+		res[model.RecordID(r.Id)] = r.Rating
+	}
+	return res, failed, nil
+}
+
+// GetRatingStats returns the rating distribution of a record or ErrNotFound if there are no ratings for it.
+func (g *Gateway) GetRatingStats(ctx context.Context, recordID model.RecordID, recordType model.RecordType) (*model.RatingStats, error) {
+	client := gen.NewRatingServiceClient(g.conn)
+	resp, err := client.GetRatingStats(ctx, &gen.GetRatingStatsRequest{Id: string(recordID), Type: string(recordType)})
+	if err != nil && status.Code(err) == codes.NotFound {
+		return nil, gateway.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return model.RatingStatsFromProto(resp.Stats), nil
+}
 
+// PutRating writes a rating or returns ErrInvalidArgument if the rating service rejects it.
+func (g *Gateway) PutRating(ctx context.Context, recordID model.RecordID, recordType model.RecordType, rating *model.Rating) error {
+	client := gen.NewRatingServiceClient(g.conn)
+	_, err := client.PutRating(ctx, &gen.PutRatingRequest{
+		UserId:      string(rating.UserID),
+		RecordId:    string(recordID),
+		RecordType:  string(recordType),
+		RatingValue: int32(rating.Value),
+	})
+	if err != nil && status.Code(err) == codes.InvalidArgument {
+		return fmt.Errorf("%w: %s", gateway.ErrInvalidArgument, status.Convert(err).Message())
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
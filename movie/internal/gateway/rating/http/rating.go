@@ -64,6 +64,66 @@ func (g *Gateway) GetAggregatedRating(ctx context.Context, recordID model.Record
 	return rating, nil
 }
 
+// BatchGetAggregatedRating returns the aggregated ratings of records of a type keyed by record id,
+// one request per record. Records without ratings are absent from the result, records that fail
+// otherwise get their error keyed by record id.
+func (g *Gateway) BatchGetAggregatedRating(ctx context.Context, recordIDs []model.RecordID, recordType model.RecordType) (map[model.RecordID]float64, map[model.RecordID]error, error) {
+	res := make(map[model.RecordID]float64, len(recordIDs))
+	var failed map[model.RecordID]error
+	for _, id := range recordIDs {
+		v, err := g.GetAggregatedRating(ctx, id, recordType)
+		if err != nil && errors.Is(err, gateway.ErrNotFound) {
+			continue
+		} else if err != nil {
+			if failed == nil {
+				failed = map[model.RecordID]error{}
+			}
+			failed[id] = err
+			continue
+		}
+		res[id] = v
+	}
+	return res, failed, nil
+}
+
+// GetRatingStats returns the rating distribution of a record or ErrNotFound if there are no ratings for it.
+func (g *Gateway) GetRatingStats(ctx context.Context, recordID model.RecordID, recordType model.RecordType) (*model.RatingStats, error) {
+
+	addrs, err := g.registry.ServiceAddress(ctx, "rating")
+	if err != nil {
+		return nil, err
+	}
+
+	url := "http://" + addrs[rand.Intn(len(addrs))] + "/rating/stats"
+	log.Printf("Calling rating service. Request: GET " + url)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	values := req.URL.Query()
+	values.Add("id", string(recordID))
+	values.Add("type", string(recordType))
+	req.URL.RawQuery = values.Encode()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, gateway.ErrNotFound
+	} else if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("non-2xx response: %v", resp)
+	}
+
+	var stats *model.RatingStats
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
 // PutRating writes a rating.
 func (g *Gateway) PutRating(ctx context.Context, recordID model.RecordID, recordType model.RecordType, rating *model.Rating) error {
 
@@ -84,14 +144,16 @@ func (g *Gateway) PutRating(ctx context.Context, recordID model.RecordID, record
 	values.Add("id", string(recordID))
 	values.Add("type", string(recordType))
 	values.Add("value", fmt.Sprintf("%v", rating.Value))
-	values.Add("user", string(rating.UserID))
+	values.Add("userId", string(rating.UserID))
 	req.URL.RawQuery = values.Encode()
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
-	if resp.StatusCode/100 != 2 {
+	if resp.StatusCode == http.StatusBadRequest {
+		return gateway.ErrInvalidArgument
+	} else if resp.StatusCode/100 != 2 {
 		return fmt.Errorf("non-2xx response: %v", resp)
 	}
 	return nil
@@ -10,6 +10,8 @@ import (
 	"movieexample.com/gen"
 	"movieexample.com/metadata/pkg/model"
 	"movieexample.com/movie/internal/controller/movie"
+	moviemodel "movieexample.com/movie/pkg/model"
+	ratingmodel "movieexample.com/rating/pkg/model"
 )
 
 // Handler defines a movie gRPC handler.
@@ -30,16 +32,74 @@ func (h *Handler) GetMovieDetails(ctx context.Context, req *gen.GetMovieDetailsR
 		return nil, status.Errorf(codes.InvalidArgument, "nil req or empty id")
 	}
 	fmt.Println("request forward to controller")
-	m, err := h.ctrl.Get(ctx, req.MovieId)
+	m, err := h.ctrl.Get(ctx, req.MovieId, req.IncludeRatingStats)
 	if err != nil && errors.Is(err, movie.ErrNotFound) {
 		return nil, status.Errorf(codes.NotFound, err.Error())
 	} else if err != nil {
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
-	return &gen.GetMovieDetailsResponse{
-		MovieDetails: &gen.MovieDetails{
-			Metadata: model.MetadataToProto(&m.Metadata),
-			Rating:   float32(*m.Rating),
-		},
-	}, nil
+	return &gen.GetMovieDetailsResponse{MovieDetails: movieDetailsToProto(m), DegradedParts: m.DegradedParts}, nil
+}
+
+// BatchGetMovieDetails returns the details of several movies.
+// Movies without metadata get a NotFound item error instead of failing the request.
+func (h *Handler) BatchGetMovieDetails(ctx context.Context, req *gen.BatchGetMovieDetailsRequest) (*gen.BatchGetMovieDetailsResponse, error) {
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "nil req")
+	}
+	for _, id := range req.MovieIds {
+		if id == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "empty id")
+		}
+	}
+	res, err := h.ctrl.BatchGet(ctx, req.MovieIds)
+	if err != nil && errors.Is(err, movie.ErrInvalidArgument) {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	resp := &gen.BatchGetMovieDetailsResponse{}
+	for _, r := range res {
+		item := &gen.MovieDetailsResult{MovieId: r.ID}
+		if r.Err != nil && errors.Is(r.Err, movie.ErrNotFound) {
+			item.Error = &gen.ItemError{Code: int32(codes.NotFound), Message: r.Err.Error()}
+		} else if r.Err != nil {
+			item.Error = &gen.ItemError{Code: int32(codes.Internal), Message: r.Err.Error()}
+		} else {
+			item.MovieDetails = movieDetailsToProto(r.Details)
+			item.DegradedParts = r.Details.DegradedParts
+		}
+		resp.Results = append(resp.Results, item)
+	}
+	return resp, nil
+}
+
+// RateMovie writes the rating of a movie by a user.
+func (h *Handler) RateMovie(ctx context.Context, req *gen.RateMovieRequest) (*gen.RateMovieResponse, error) {
+	if req == nil || req.MovieId == "" || req.UserId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "nil req or empty movie id or user id")
+	}
+	err := h.ctrl.Rate(ctx, req.MovieId, ratingmodel.UserID(req.UserId), ratingmodel.RatingValue(req.RatingValue))
+	if err != nil && errors.Is(err, movie.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, err.Error())
+	} else if err != nil && errors.Is(err, movie.ErrInvalidArgument) {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	return &gen.RateMovieResponse{}, nil
+}
+
+func movieDetailsToProto(m *moviemodel.MovieDetails) *gen.MovieDetails {
+	details := &gen.MovieDetails{
+		Metadata: model.MetadataToProto(&m.Metadata),
+	}
+	if m.Rating != nil {
+		details.Rating = float32(*m.Rating)
+		details.HasRating = true
+	}
+	if m.RatingStats != nil {
+		details.RatingStats = ratingmodel.RatingStatsToProto(m.RatingStats)
+	}
+	return details
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"log"
 	"net/http"
+	"strconv"
 
 	"movieexample.com/movie/internal/controller/movie"
 )
@@ -20,7 +21,8 @@ func New(ctrl *movie.Controller) *Handler {
 
 func (h *Handler) GetMovieDetails(w http.ResponseWriter, r *http.Request) {
 	id := r.FormValue("id")
-	details, err := h.ctrl.Get(r.Context(), id)
+	withStats, _ := strconv.ParseBool(r.FormValue("stats"))
+	details, err := h.ctrl.Get(r.Context(), id, withStats)
 	if err != nil && errors.Is(err, movie.ErrNotFound) {
 		w.WriteHeader(http.StatusNotFound)
 		return
@@ -1,8 +1,21 @@
 package model
 
-import model "movieexample.com/metadata/pkg/model"
+import (
+	model "movieexample.com/metadata/pkg/model"
+	ratingmodel "movieexample.com/rating/pkg/model"
+)
+
+// Parts of movie details that can be degraded.
+const (
+	PartRating      = "rating"
+	PartRatingStats = "rating_stats"
+)
 
 type MovieDetails struct {
-	Rating   *float64       `json:"rating,omitempty"`
-	Metadata model.Metadata `json:"metadata"`
+	// Rating is nil if the movie has no ratings yet or the rating is degraded.
+	Rating      *float64                 `json:"rating,omitempty"`
+	RatingStats *ratingmodel.RatingStats `json:"rating_stats,omitempty"`
+	Metadata    model.Metadata           `json:"metadata"`
+	// DegradedParts names the parts that could not be retrieved.
+	DegradedParts []string `json:"degraded_parts,omitempty"`
 }
@@ -4,8 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	consul "github.com/hashicorp/consul/api"
 	"movieexample.com/pkg/discovery"
@@ -65,12 +69,102 @@ func (r *Registry) ServiceAddress(ctx context.Context, serviceName string) ([]st
 	} else if len(entries) == 0 {
 		return nil, discovery.ErrNotFound
 	}
-	var res []string
+	return addresses(entries), nil
+
+}
+
+// watchRetryDelay defines how long a watch waits before repeating a failed blocking query.
+const watchRetryDelay = time.Second
+
+// Watch streams the sorted addresses of the healthy instances of the given service whenever they change.
+// The changes are awaited with Consul blocking queries. A failure of the first query is returned,
+// later failures are logged and the query is repeated.
+func (r *Registry) Watch(ctx context.Context, serviceName string) (<-chan []string, error) {
+	return watch(ctx, serviceName, func(ctx context.Context, index uint64) ([]*consul.ServiceEntry, uint64, error) {
+		entries, meta, err := r.client.Health().Service(serviceName, "", true, (&consul.QueryOptions{WaitIndex: index}).WithContext(ctx))
+		if err != nil {
+			return nil, 0, err
+		}
+		return entries, meta.LastIndex, nil
+	})
+}
+
+// healthQuery returns the healthy instances of a service and the index of the result. With a
+// non-zero index it blocks until the result changes after that index.
+type healthQuery func(ctx context.Context, index uint64) ([]*consul.ServiceEntry, uint64, error)
+
+// watch streams the addresses of the results of a health query whenever they change.
+func watch(ctx context.Context, serviceName string, query healthQuery) (<-chan []string, error) {
+	entries, index, err := query(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	var w serviceWatch
+	addrs, changed := w.update(entries, index)
+	ch := make(chan []string)
+	go func() {
+		defer close(ch)
+		for {
+			if changed {
+				select {
+				case ch <- addrs:
+				case <-ctx.Done():
+					return
+				}
+			}
+			for {
+				entries, index, err = query(ctx, w.index)
+				if ctx.Err() != nil {
+					return
+				} else if err == nil {
+					break
+				}
+				log.Printf("Failed to watch service %s: %v\n", serviceName, err)
+				select {
+				case <-time.After(watchRetryDelay):
+				case <-ctx.Done():
+					return
+				}
+			}
+			addrs, changed = w.update(entries, index)
+		}
+	}()
+	return ch, nil
+}
+
+// serviceWatch keeps the state of a watch between blocking queries.
+type serviceWatch struct {
+	// index is the wait index of the next query.
+	index   uint64
+	last    []string
+	started bool
+}
+
+// update records the result of a query and returns its sorted addresses and whether they changed.
+// The first result always counts as a change.
+func (w *serviceWatch) update(entries []*consul.ServiceEntry, index uint64) ([]string, bool) {
+	// Consul may reset its index, starting over avoids blocking on an index that will not come.
+	if index < w.index {
+		w.index = 0
+	} else {
+		w.index = index
+	}
+	addrs := addresses(entries)
+	if w.started && slices.Equal(addrs, w.last) {
+		return addrs, false
+	}
+	w.started, w.last = true, addrs
+	return addrs, true
+}
+
+// addresses returns the sorted host:port addresses of service entries.
+func addresses(entries []*consul.ServiceEntry) []string {
+	res := make([]string, 0, len(entries))
 	for _, e := range entries {
 		res = append(res, fmt.Sprintf("%s:%d", e.Service.Address, e.Service.Port))
 	}
-	return res, nil
-
+	sort.Strings(res)
+	return res
 }
 
 // ReportHealthyState is a push mechanism for reporting healthy state to the registry.
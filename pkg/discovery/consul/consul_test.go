@@ -0,0 +1,113 @@
+package consul
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	consul "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func entries(addrs ...int) []*consul.ServiceEntry {
+	var res []*consul.ServiceEntry
+	for _, port := range addrs {
+		res = append(res, &consul.ServiceEntry{Service: &consul.AgentService{Address: "localhost", Port: port}})
+	}
+	return res
+}
+
+func TestServiceWatchUpdate(t *testing.T) {
+	var w serviceWatch
+	steps := []struct {
+		name        string
+		entries     []*consul.ServiceEntry
+		index       uint64
+		wantAddrs   []string
+		wantChanged bool
+		wantIndex   uint64
+	}{
+		{name: "first", entries: entries(8083, 8082), index: 10, wantAddrs: []string{"localhost:8082", "localhost:8083"}, wantChanged: true, wantIndex: 10},
+		{name: "same instances", entries: entries(8082, 8083), index: 12, wantAddrs: []string{"localhost:8082", "localhost:8083"}, wantIndex: 12},
+		{name: "instance left", entries: entries(8083), index: 15, wantAddrs: []string{"localhost:8083"}, wantChanged: true, wantIndex: 15},
+		{name: "index reset", entries: entries(8083), index: 3, wantAddrs: []string{"localhost:8083"}, wantIndex: 0},
+		{name: "after reset", entries: entries(), index: 4, wantAddrs: []string{}, wantChanged: true, wantIndex: 4},
+	}
+	for _, s := range steps {
+		addrs, changed := w.update(s.entries, s.index)
+		assert.Equal(t, s.wantAddrs, addrs, s.name)
+		assert.Equal(t, s.wantChanged, changed, s.name)
+		assert.Equal(t, s.wantIndex, w.index, s.name)
+	}
+}
+
+// scriptedQuery returns the scripted results in order and then blocks until the context is cancelled.
+type scriptedQuery struct {
+	mu      sync.Mutex
+	results []scriptedResult
+	indexes []uint64
+}
+
+type scriptedResult struct {
+	entries []*consul.ServiceEntry
+	index   uint64
+}
+
+func (q *scriptedQuery) query(ctx context.Context, index uint64) ([]*consul.ServiceEntry, uint64, error) {
+	q.mu.Lock()
+	q.indexes = append(q.indexes, index)
+	if len(q.results) > 0 {
+		r := q.results[0]
+		q.results = q.results[1:]
+		q.mu.Unlock()
+		return r.entries, r.index, nil
+	}
+	q.mu.Unlock()
+	<-ctx.Done()
+	return nil, 0, ctx.Err()
+}
+
+func TestWatch(t *testing.T) {
+	q := &scriptedQuery{results: []scriptedResult{
+		{entries(8082), 5},
+		{entries(8082), 7},
+		{entries(8082, 8083), 9},
+		{entries(8083), 2},
+		{entries(8083), 3},
+	}}
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := watch(ctx, "rating", q.query)
+	require.NoError(t, err)
+
+	for _, want := range [][]string{{"localhost:8082"}, {"localhost:8082", "localhost:8083"}, {"localhost:8083"}} {
+		select {
+		case got := <-ch:
+			assert.Equal(t, want, got)
+		case <-time.After(time.Second):
+			t.Fatalf("no update %v", want)
+		}
+	}
+	assert.Eventually(t, func() bool {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		return len(q.indexes) == 6
+	}, time.Second, time.Millisecond)
+	q.mu.Lock()
+	assert.Equal(t, []uint64{0, 5, 7, 9, 0, 3}, q.indexes, "the wait index starts over once consul resets it")
+	q.mu.Unlock()
+
+	cancel()
+	_, ok := <-ch
+	assert.False(t, ok, "the watch ends with its context")
+}
+
+func TestWatchFirstQueryFails(t *testing.T) {
+	failing := func(ctx context.Context, index uint64) ([]*consul.ServiceEntry, uint64, error) {
+		return nil, 0, errors.New("connection refused")
+	}
+	_, err := watch(context.Background(), "rating", failing)
+	assert.EqualError(t, err, "connection refused")
+}
@@ -15,6 +15,10 @@ type Registry interface {
 	Register(ctx context.Context, instanceID string, serviceName string, hostPort string) error
 	Deregister(ctx context.Context, instanceID string, serviceName string) error
 	ServiceAddress(ctx context.Context, serviceName string) ([]string, error)
+	// Watch streams the sorted addresses of the active instances of a service, starting with the
+	// current ones and then whenever the set changes. An empty set means there are no active instances.
+	// The channel is closed once the context is done.
+	Watch(ctx context.Context, serviceName string) (<-chan []string, error)
 
 	// ReportHealthyState is a push mechanism for reporting healthy state to the registry.
 	ReportHealtyState(instanceID string, serviceName string) error
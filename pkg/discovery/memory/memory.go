@@ -3,6 +3,8 @@ package memory
 import (
 	"context"
 	"errors"
+	"slices"
+	"sort"
 	"sync"
 	"time"
 
@@ -17,14 +19,25 @@ type serviceInstance struct {
 	lastActive time.Time
 }
 
+// instanceTTL defines how long an instance stays active after it last reported a healthy state.
+const instanceTTL = 5 * time.Second
+
 type Registry struct {
 	sync.RWMutex
 	serviceAddrs map[serviceName]map[instanceID]*serviceInstance
+	// watchers holds a notifying channel per watch of a service.
+	watchers map[serviceName]map[chan struct{}]bool
+	// pollInterval defines how often watches look for instances that stopped reporting a healthy state.
+	pollInterval time.Duration
 }
 
 // NewRegistry creates a new in-memory service registry instance
 func NewRegistry() *Registry {
-	return &Registry{serviceAddrs: map[serviceName]map[instanceID]*serviceInstance{}}
+	return &Registry{
+		serviceAddrs: map[serviceName]map[instanceID]*serviceInstance{},
+		watchers:     map[serviceName]map[chan struct{}]bool{},
+		pollInterval: time.Second,
+	}
 }
 
 // Register creates a service record in the registry.
@@ -37,6 +50,7 @@ func (r *Registry) Register(ctx context.Context, ID string, name string, addr st
 		r.serviceAddrs[s] = map[instanceID]*serviceInstance{}
 	}
 	r.serviceAddrs[s][i] = &serviceInstance{hostPort: addr, lastActive: time.Now()}
+	r.notify(s)
 	return nil
 }
 
@@ -48,6 +62,7 @@ func (r *Registry) Deregister(ctx context.Context, ID string, name string) error
 	defer r.Unlock()
 	if _, ok := r.serviceAddrs[s]; ok {
 		delete(r.serviceAddrs[s], i)
+		r.notify(s)
 	}
 	return nil
 }
@@ -64,6 +79,9 @@ func (r *Registry) ReportHealtyState(ID string, name string) error {
 	if _, ok := r.serviceAddrs[s][i]; !ok {
 		return errors.New("service instance is not registered yet")
 	}
+	if !r.serviceAddrs[s][i].active() {
+		r.notify(s)
+	}
 	r.serviceAddrs[s][i].lastActive = time.Now()
 	return nil
 }
@@ -79,14 +97,83 @@ func (r *Registry) ServiceAddress(ctx context.Context, name string) ([]string, e
 	if len(r.serviceAddrs[s]) == 0 {
 		return nil, discovery.ErrNotFound
 	}
+	return r.activeAddrs(s), nil
+}
+
+// Watch streams the sorted addresses of the active instances of the given service whenever they change.
+// Registrations wake up the watch through its notifying channel, instances that stop reporting a
+// healthy state are noticed within a second.
+func (r *Registry) Watch(ctx context.Context, name string) (<-chan []string, error) {
+	s := serviceName(name)
+	notify := make(chan struct{}, 1)
+	r.Lock()
+	if _, ok := r.watchers[s]; !ok {
+		r.watchers[s] = map[chan struct{}]bool{}
+	}
+	r.watchers[s][notify] = true
+	pollInterval := r.pollInterval
+	r.Unlock()
+
+	ch := make(chan []string)
+	go func() {
+		defer close(ch)
+		defer func() {
+			r.Lock()
+			delete(r.watchers[s], notify)
+			r.Unlock()
+		}()
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+		var last []string
+		for first := true; ; first = false {
+			r.RLock()
+			addrs := r.activeAddrs(s)
+			r.RUnlock()
+			if first || !slices.Equal(addrs, last) {
+				select {
+				case ch <- addrs:
+				case <-ctx.Done():
+					return
+				}
+				last = addrs
+			}
+			select {
+			case <-notify:
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// activeAddrs returns the sorted addresses of the active instances of a service.
+// The caller must hold the lock.
+func (r *Registry) activeAddrs(s serviceName) []string {
 	addrs := make([]string, 0, len(r.serviceAddrs[s]))
 	for _, instance := range r.serviceAddrs[s] {
-		if instance.lastActive.Before(time.Now().Add(-5 * time.Second)) {
+		if !instance.active() {
 			continue
 		}
 		addrs = append(addrs, instance.hostPort)
 	}
-	return addrs, nil
+	sort.Strings(addrs)
+	return addrs
+}
+
+// notify wakes up the watches of a service. The caller must hold the lock.
+func (r *Registry) notify(s serviceName) {
+	for w := range r.watchers[s] {
+		select {
+		case w <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (i *serviceInstance) active() bool {
+	return !i.lastActive.Before(time.Now().Add(-instanceTTL))
 }
 
 
@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expire makes an instance look like it stopped reporting a healthy state.
+func expire(r *Registry, ID string, name string) {
+	r.Lock()
+	defer r.Unlock()
+	r.serviceAddrs[serviceName(name)][instanceID(ID)].lastActive = time.Now().Add(-instanceTTL - time.Second)
+}
+
+func next(t *testing.T, ch <-chan []string) []string {
+	t.Helper()
+	select {
+	case addrs := <-ch:
+		return addrs
+	case <-time.After(time.Second):
+		t.Fatal("no watch update")
+		return nil
+	}
+}
+
+func TestWatchExpiresAndReactivates(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := NewRegistry()
+	r.pollInterval = 10 * time.Millisecond
+	require.NoError(t, r.Register(ctx, "rating-1", "rating", "localhost:8082"))
+	require.NoError(t, r.Register(ctx, "rating-2", "rating", "localhost:8083"))
+	ch, err := r.Watch(ctx, "rating")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"localhost:8082", "localhost:8083"}, next(t, ch))
+
+	expire(r, "rating-1", "rating")
+	assert.Equal(t, []string{"localhost:8083"}, next(t, ch), "an instance past its TTL leaves the watch")
+
+	// Only the notification of the reactivation can wake up the watch before the next poll.
+	r.Lock()
+	r.pollInterval = time.Hour
+	r.Unlock()
+	ch, err = r.Watch(ctx, "rating")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"localhost:8083"}, next(t, ch))
+	require.NoError(t, r.ReportHealtyState("rating-1", "rating"))
+	assert.Equal(t, []string{"localhost:8082", "localhost:8083"}, next(t, ch), "a reactivated instance rejoins the watch")
+}
+
+func TestWatchCleansUpOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := NewRegistry()
+	require.NoError(t, r.Register(ctx, "rating-1", "rating", "localhost:8082"))
+	ch, err := r.Watch(ctx, "rating")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"localhost:8082"}, next(t, ch))
+
+	cancel()
+	for range ch {
+	}
+	r.RLock()
+	defer r.RUnlock()
+	assert.Empty(t, r.watchers["rating"], "a cancelled watch removes its notifying channel")
+}
@@ -0,0 +1,63 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"movieexample.com/pkg/outbox"
+)
+
+// HeaderEventType defines the message header naming the type of an outbox event.
+const HeaderEventType = "event-type"
+
+// Publisher publishes outbox events to a Kafka topic, keyed by their aggregate id
+// so that the events of an aggregate stay in order.
+type Publisher struct {
+	producer *kafka.Producer
+	topic    string
+}
+
+// NewPublisher creates a publisher to the given topic.
+func NewPublisher(addr string, topic string) (*Publisher, error) {
+	producer, err := kafka.NewProducer(&kafka.ConfigMap{
+		"bootstrap.servers":  addr,
+		"enable.idempotence": true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Publisher{producer: producer, topic: topic}, nil
+}
+
+// Publish produces the events and waits until all of them are delivered.
+func (p *Publisher) Publish(ctx context.Context, events []outbox.Event) error {
+	delivery := make(chan kafka.Event, len(events))
+	for _, e := range events {
+		if err := p.producer.Produce(&kafka.Message{
+			TopicPartition: kafka.TopicPartition{Topic: &p.topic, Partition: kafka.PartitionAny},
+			Key:            []byte(e.AggregateID),
+			Value:          e.Payload,
+			Headers:        []kafka.Header{{Key: HeaderEventType, Value: []byte(e.Type)}},
+			Timestamp:      e.CreatedAt,
+		}, delivery); err != nil {
+			return err
+		}
+	}
+	var err error
+	for range events {
+		select {
+		case e := <-delivery:
+			if m, ok := e.(*kafka.Message); ok && m.TopicPartition.Error != nil && err == nil {
+				err = m.TopicPartition.Error
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return err
+}
+
+// Close closes the producer.
+func (p *Publisher) Close() {
+	p.producer.Close()
+}
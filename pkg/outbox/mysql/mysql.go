@@ -0,0 +1,88 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"movieexample.com/pkg/outbox"
+)
+
+// Insert writes events to the outbox table as part of a transaction.
+func Insert(ctx context.Context, tx *sql.Tx, table string, events ...outbox.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	args := make([]any, 0, len(events)*4)
+	for _, e := range events {
+		args = append(args, e.Type, e.AggregateID, e.Payload, e.CreatedAt)
+	}
+	rows := strings.TrimSuffix(strings.Repeat("(?, ?, ?, ?), ", len(events)), ", ")
+	_, err := tx.ExecContext(ctx, "INSERT INTO "+table+" (event_type, aggregate_id, payload, created_at) VALUES "+rows, args...)
+	return err
+}
+
+// Store defines an outbox stored in a MySQL table.
+type Store struct {
+	db    *sql.DB
+	table string
+}
+
+// NewStore creates an outbox stored in the given table.
+func NewStore(db *sql.DB, table string) *Store {
+	return &Store{db: db, table: table}
+}
+
+// Pending returns up to limit unpublished events in the order they were written.
+func (s *Store) Pending(ctx context.Context, limit int) ([]outbox.Event, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT id, event_type, aggregate_id, payload, created_at FROM "+s.table+" WHERE published_at IS NULL ORDER BY id LIMIT ?", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var res []outbox.Event
+	for rows.Next() {
+		var e outbox.Event
+		if err := rows.Scan(&e.ID, &e.Type, &e.AggregateID, &e.Payload, timestamp{&e.CreatedAt}); err != nil {
+			return nil, err
+		}
+		res = append(res, e)
+	}
+	return res, rows.Err()
+}
+
+// MarkPublished records the publication of events.
+func (s *Store) MarkPublished(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	_, err := s.db.ExecContext(ctx, "UPDATE "+s.table+" SET published_at = CURRENT_TIMESTAMP(6) WHERE id IN ("+strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")+")", args...)
+	return err
+}
+
+// timestamp scans a TIMESTAMP column with or without the parseTime option of the connection.
+type timestamp struct {
+	t *time.Time
+}
+
+func (s timestamp) Scan(v any) error {
+	switch v := v.(type) {
+	case time.Time:
+		*s.t = v
+	case []byte:
+		t, err := time.Parse("2006-01-02 15:04:05.999999", string(v))
+		if err != nil {
+			return err
+		}
+		*s.t = t
+	default:
+		return fmt.Errorf("unsupported timestamp %T", v)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+)
+
+// Event types written to the outboxes.
+const (
+	TypeRatingChanged   = "RatingChanged"
+	TypeMetadataChanged = "MetadataChanged"
+)
+
+// Event defines a change event waiting in an outbox to be published.
+type Event struct {
+	// ID orders the events of an outbox. It is assigned by the outbox.
+	ID          int64     `json:"id"`
+	Type        string    `json:"type"`
+	AggregateID string    `json:"aggregate_id"`
+	Payload     []byte    `json:"payload"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// NewEvent creates an event with the JSON encoding of the payload.
+func NewEvent(eventType string, aggregateID string, payload any) (Event, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, err
+	}
+	return Event{Type: eventType, AggregateID: aggregateID, Payload: b, CreatedAt: time.Now().UTC()}, nil
+}
+
+// Store defines an outbox the relay reads the unpublished events from.
+type Store interface {
+	// Pending returns up to limit unpublished events in the order they were written.
+	Pending(ctx context.Context, limit int) ([]Event, error)
+	MarkPublished(ctx context.Context, ids []int64) error
+}
+
+// Publisher defines a destination of outbox events.
+type Publisher interface {
+	Publish(ctx context.Context, events []Event) error
+}
+
+// Relay publishes the events of an outbox. Events are published at least once:
+// an event whose publication was not recorded is published again.
+type Relay struct {
+	store     Store
+	publisher Publisher
+	interval  time.Duration
+	batchSize int
+}
+
+// NewRelay creates a relay that checks the outbox for new events at the given interval.
+func NewRelay(store Store, publisher Publisher, interval time.Duration) *Relay {
+	return &Relay{store: store, publisher: publisher, interval: interval, batchSize: 100}
+}
+
+// Run publishes outbox events until the context is cancelled.
+func (r *Relay) Run(ctx context.Context) error {
+	for {
+		n, err := r.publishPending(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Failed to relay outbox events: %v\n", err)
+		}
+		if err == nil && n == r.batchSize {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(r.interval):
+		}
+	}
+}
+
+// publishPending publishes a batch of pending events and returns its size.
+func (r *Relay) publishPending(ctx context.Context) (int, error) {
+	events, err := r.store.Pending(ctx, r.batchSize)
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+	if err := r.publisher.Publish(ctx, events); err != nil {
+		return 0, err
+	}
+	ids := make([]int64, len(events))
+	for i, e := range events {
+		ids[i] = e.ID
+	}
+	return len(events), r.store.MarkPublished(ctx, ids)
+}
+
+// Handler defines a subscriber of in-process events.
+type Handler func(ctx context.Context, e Event) error
+
+// LocalPublisher publishes events to in-process subscribers.
+type LocalPublisher struct {
+	sync.RWMutex
+	handlers []Handler
+}
+
+// NewLocalPublisher creates a publisher to in-process subscribers.
+func NewLocalPublisher() *LocalPublisher {
+	return &LocalPublisher{}
+}
+
+// Subscribe registers a handler that is called for every published event.
+func (p *LocalPublisher) Subscribe(h Handler) {
+	p.Lock()
+	defer p.Unlock()
+	p.handlers = append(p.handlers, h)
+}
+
+// Publish calls the handlers for every event in order. It stops at the first handler error.
+func (p *LocalPublisher) Publish(ctx context.Context, events []Event) error {
+	p.RLock()
+	defer p.RUnlock()
+	for _, e := range events {
+		for _, h := range p.handlers {
+			if err := h(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MemoryStore defines an in-memory outbox.
+type MemoryStore struct {
+	sync.Mutex
+	events []Event
+	nextID int64
+}
+
+// NewMemoryStore creates an in-memory outbox.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{nextID: 1}
+}
+
+// Add appends events to the outbox.
+func (s *MemoryStore) Add(events ...Event) {
+	s.Lock()
+	defer s.Unlock()
+	for _, e := range events {
+		e.ID = s.nextID
+		s.nextID++
+		s.events = append(s.events, e)
+	}
+}
+
+// Pending returns up to limit unpublished events in the order they were added.
+func (s *MemoryStore) Pending(_ context.Context, limit int) ([]Event, error) {
+	s.Lock()
+	defer s.Unlock()
+	n := min(limit, len(s.events))
+	return append([]Event(nil), s.events[:n]...), nil
+}
+
+// MarkPublished removes published events from the outbox.
+func (s *MemoryStore) MarkPublished(_ context.Context, ids []int64) error {
+	s.Lock()
+	defer s.Unlock()
+	published := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		published[id] = true
+	}
+	res := s.events[:0]
+	for _, e := range s.events {
+		if !published[e.ID] {
+			res = append(res, e)
+		}
+	}
+	s.events = res
+	return nil
+}
@@ -0,0 +1,53 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelay(t *testing.T) {
+	store := NewMemoryStore()
+	for _, id := range []string{"1", "2", "1"} {
+		e, err := NewEvent(TypeMetadataChanged, id, map[string]string{"id": id})
+		require.NoError(t, err)
+		store.Add(e)
+	}
+	publisher := NewLocalPublisher()
+	published := make(chan Event, 10)
+	failures := 1
+	publisher.Subscribe(func(ctx context.Context, e Event) error {
+		if failures > 0 {
+			failures--
+			return errors.New("unavailable")
+		}
+		published <- e
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		NewRelay(store, publisher, time.Millisecond).Run(ctx)
+	}()
+	var ids []int64
+	for len(ids) < 3 {
+		select {
+		case e := <-published:
+			ids = append(ids, e.ID)
+		case <-time.After(time.Second):
+			t.Fatal("events were not published")
+		}
+	}
+	cancel()
+	<-done
+	assert.Equal(t, []int64{1, 2, 3}, ids, "events are published in order after a failed attempt")
+	pending, err := store.Pending(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+}
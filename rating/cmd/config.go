@@ -1,5 +1,12 @@
 package main
 
+import (
+	"fmt"
+	"time"
+
+	"movieexample.com/rating/pkg/model"
+)
+
 type apiConfig struct {
 	Port string `yaml:"port"`
 }
@@ -7,7 +14,110 @@ type jaegerConfig struct {
 	URL string `yaml:"url"`
 }
 
+type bayesianConfig struct {
+	PriorMean   float64 `yaml:"prior_mean"`
+	PriorWeight float64 `yaml:"prior_weight"`
+}
+
+type timeDecayConfig struct {
+	HalfLife time.Duration `yaml:"half_life"`
+}
+
+type trimmedMeanConfig struct {
+	Fraction float64 `yaml:"fraction"`
+}
+
+// aggregationConfig overrides the parameters of the built-in aggregation strategies.
+// The built-in parameters of a strategy are kept if its section is missing.
+type aggregationConfig struct {
+	Default     string             `yaml:"default"`
+	Bayesian    *bayesianConfig    `yaml:"bayesian"`
+	TimeDecay   *timeDecayConfig   `yaml:"time_decay"`
+	TrimmedMean *trimmedMeanConfig `yaml:"trimmed_mean"`
+}
+
+type kafkaConfig struct {
+	Broker  string `yaml:"broker"`
+	GroupID string `yaml:"group_id"`
+	Topic   string `yaml:"topic"`
+	// The ingestion keys below moved to the ingestion section. They are still read for older configs.
+	DeadLetterTopic string       `yaml:"dead_letter_topic"`
+	DeadLetterFile  string       `yaml:"dead_letter_file"`
+	Retry           *retryConfig `yaml:"retry"`
+	Batch           *batchConfig `yaml:"batch"`
+}
+
+type ingestionConfig struct {
+	// File is an NDJSON file of rating events, or - for stdin, that is ingested instead of Kafka.
+	File       string `yaml:"file"`
+	Checkpoint string `yaml:"checkpoint"`
+	// CheckpointInterval defines how often the checkpoint is written.
+	CheckpointInterval time.Duration `yaml:"checkpoint_interval"`
+	Follow             time.Duration `yaml:"follow"`
+	// DeadLetterTopic receives the rating events that cannot be ingested.
+	// DeadLetterFile is used instead if no topic is set.
+	DeadLetterTopic string      `yaml:"dead_letter_topic"`
+	DeadLetterFile  string      `yaml:"dead_letter_file"`
+	Retry           retryConfig `yaml:"retry"`
+	Batch           batchConfig `yaml:"batch"`
+}
+
+type batchConfig struct {
+	Size     int           `yaml:"size"`
+	Interval time.Duration `yaml:"interval"`
+}
+
+type retryConfig struct {
+	MaxAttempts int           `yaml:"max_attempts"`
+	Backoff     time.Duration `yaml:"backoff"`
+}
+
+type outboxConfig struct {
+	// Topic receives the rating change events. They are only published in-process if it is empty.
+	Topic    string        `yaml:"topic"`
+	Interval time.Duration `yaml:"interval"`
+}
+
 type serverConfig struct {
-	API    apiConfig    `yaml:"api"`
-	Jaeger jaegerConfig `yaml:"jaeger"`
+	API         apiConfig           `yaml:"api"`
+	Jaeger      jaegerConfig        `yaml:"jaeger"`
+	Aggregation aggregationConfig   `yaml:"aggregation"`
+	Scales      model.ScaleRegistry `yaml:"scales"`
+	Kafka       kafkaConfig         `yaml:"kafka"`
+	Ingestion   ingestionConfig     `yaml:"ingestion"`
+	Outbox      outboxConfig        `yaml:"outbox"`
+}
+
+// moveLegacyKafkaKeys moves the ingestion keys that used to live in the kafka section to the
+// ingestion section. It fails if a key is set in both sections, so that no value is silently ignored.
+func (c *serverConfig) moveLegacyKafkaKeys() error {
+	if c.Kafka.DeadLetterTopic != "" {
+		if c.Ingestion.DeadLetterTopic != "" {
+			return legacyKeyError("dead_letter_topic")
+		}
+		c.Ingestion.DeadLetterTopic = c.Kafka.DeadLetterTopic
+	}
+	if c.Kafka.DeadLetterFile != "" {
+		if c.Ingestion.DeadLetterFile != "" {
+			return legacyKeyError("dead_letter_file")
+		}
+		c.Ingestion.DeadLetterFile = c.Kafka.DeadLetterFile
+	}
+	if c.Kafka.Retry != nil {
+		if c.Ingestion.Retry != (retryConfig{}) {
+			return legacyKeyError("retry")
+		}
+		c.Ingestion.Retry = *c.Kafka.Retry
+	}
+	if c.Kafka.Batch != nil {
+		if c.Ingestion.Batch != (batchConfig{}) {
+			return legacyKeyError("batch")
+		}
+		c.Ingestion.Batch = *c.Kafka.Batch
+	}
+	return nil
+}
+
+func legacyKeyError(key string) error {
+	return fmt.Errorf("kafka.%s moved to ingestion.%s, set it only in the ingestion section", key, key)
 }
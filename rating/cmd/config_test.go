@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMoveLegacyKafkaKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		want    ingestionConfig
+		wantErr string
+	}{
+		{
+			name: "legacy keys",
+			config: `
+kafka:
+  dead_letter_topic: ratings-dlq
+  dead_letter_file: dlq.ndjson
+  retry:
+    max_attempts: 3
+    backoff: 100ms
+  batch:
+    size: 500
+    interval: 1s
+ingestion:
+  follow: 1s
+`,
+			want: ingestionConfig{
+				Follow:          time.Second,
+				DeadLetterTopic: "ratings-dlq",
+				DeadLetterFile:  "dlq.ndjson",
+				Retry:           retryConfig{MaxAttempts: 3, Backoff: 100 * time.Millisecond},
+				Batch:           batchConfig{Size: 500, Interval: time.Second},
+			},
+		},
+		{
+			name: "ingestion keys",
+			config: `
+ingestion:
+  dead_letter_topic: ratings-dlq
+  retry:
+    max_attempts: 2
+`,
+			want: ingestionConfig{DeadLetterTopic: "ratings-dlq", Retry: retryConfig{MaxAttempts: 2}},
+		},
+		{
+			name: "both sections",
+			config: `
+kafka:
+  retry:
+    max_attempts: 3
+ingestion:
+  retry:
+    max_attempts: 2
+`,
+			wantErr: "kafka.retry moved to ingestion.retry, set it only in the ingestion section",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg serverConfig
+			require.NoError(t, yaml.Unmarshal([]byte(tt.config), &cfg))
+			err := cfg.moveLegacyKafkaKeys()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, cfg.Ingestion)
+		})
+	}
+}
@@ -20,9 +20,15 @@ import (
 	"movieexample.com/gen"
 	"movieexample.com/pkg/discovery"
 	"movieexample.com/pkg/discovery/consul"
+	"movieexample.com/pkg/outbox"
+	outboxkafka "movieexample.com/pkg/outbox/kafka"
 	"movieexample.com/pkg/tracing"
+	"movieexample.com/rating/internal/aggregation"
 	"movieexample.com/rating/internal/controller/rating"
 	grpchandler "movieexample.com/rating/internal/handler/grpc"
+	"movieexample.com/rating/internal/ingester/deadletter"
+	"movieexample.com/rating/internal/ingester/file"
+	"movieexample.com/rating/internal/ingester/kafka"
 	"movieexample.com/rating/internal/repository/mysql"
 )
 
@@ -45,6 +51,9 @@ func main() {
 	if err := yaml.NewDecoder(f).Decode(&cfg); err != nil {
 		panic(err)
 	}
+	if err := cfg.moveLegacyKafkaKeys(); err != nil {
+		panic(err)
+	}
 	port := cfg.API.Port
 
 	registry, err := consul.NewRegistry("localhost:8500")
@@ -75,7 +84,25 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	ctrl := rating.New(repo)
+	opts, err := aggregationOptions(cfg.Aggregation)
+	if err != nil {
+		panic(err)
+	}
+	if len(cfg.Scales) > 0 {
+		opts = append(opts, rating.WithScales(cfg.Scales))
+	}
+	ingestionOpts, closeIngestion, err := ingestionOptions(cfg)
+	if err != nil {
+		panic(err)
+	}
+	defer closeIngestion()
+	opts = append(opts, ingestionOpts...)
+	ctrl := rating.New(repo, opts...)
+	relay, closeRelay, err := outboxRelay(cfg, repo.Outbox())
+	if err != nil {
+		panic(err)
+	}
+	defer closeRelay()
 	h := grpchandler.New(ctrl)
 	lis, err := net.Listen("tcp", fmt.Sprintf("localhost:%v", port))
 	if err != nil {
@@ -86,6 +113,26 @@ func main() {
 	gen.RegisterRatingServiceServer(srv, h)
 	reflection.Register(srv)
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		relay.Run(ctx)
+		log.Println("rating outbox relay stopped")
+	}()
+
+	if len(ingestionOpts) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			log.Println("Starting rating ingestion")
+			if err := ctrl.StartIngestion(ctx); err != nil {
+				log.Printf("Rating ingestion failed: %v\n", err)
+				return
+			}
+			log.Println("rating ingestion stopped")
+		}()
+	}
+
 	go func() {
 		defer wg.Done()
 		s := <-quit
@@ -103,6 +150,124 @@ func main() {
 	wg.Wait()
 }
 
+// aggregationOptions returns the controller options of the configured aggregation strategies.
+// It returns an error if the default strategy is not one of the built-in strategies.
+func aggregationOptions(cfg aggregationConfig) ([]rating.Option, error) {
+	var opts []rating.Option
+	if cfg.Bayesian != nil {
+		opts = append(opts, rating.WithStrategy(aggregation.NameBayesian, aggregation.Bayesian{
+			PriorMean:   cfg.Bayesian.PriorMean,
+			PriorWeight: cfg.Bayesian.PriorWeight,
+		}))
+	}
+	if cfg.TimeDecay != nil {
+		opts = append(opts, rating.WithStrategy(aggregation.NameTimeDecay, aggregation.TimeDecay{HalfLife: cfg.TimeDecay.HalfLife}))
+	}
+	if cfg.TrimmedMean != nil {
+		opts = append(opts, rating.WithStrategy(aggregation.NameTrimmedMean, aggregation.TrimmedMean{Fraction: cfg.TrimmedMean.Fraction}))
+	}
+	switch cfg.Default {
+	case "":
+	case aggregation.NameMean, aggregation.NameBayesian, aggregation.NameTimeDecay, aggregation.NameTrimmedMean:
+		opts = append(opts, rating.WithDefaultStrategy(cfg.Default))
+	default:
+		return nil, fmt.Errorf("%w: default %q", rating.ErrUnknownStrategy, cfg.Default)
+	}
+	return opts, nil
+}
+
+// ingestionOptions returns the controller options of rating ingestion from the file if one is set
+// or from Kafka otherwise, and a function that closes the file ingester and the dead-letter sink.
+// It returns no options if ingestion is disabled.
+func ingestionOptions(cfg serverConfig) ([]rating.Option, func(), error) {
+	if cfg.Ingestion.File == "" && cfg.Kafka.Broker == "" {
+		return nil, func() {}, nil
+	}
+	var sink deadletter.Sink
+	closeSink := func() {}
+	switch {
+	case cfg.Ingestion.DeadLetterTopic != "" && cfg.Kafka.Broker != "":
+		p, err := kafka.NewDeadLetterProducer(cfg.Kafka.Broker, cfg.Ingestion.DeadLetterTopic)
+		if err != nil {
+			return nil, nil, err
+		}
+		sink, closeSink = p, p.Close
+	case cfg.Ingestion.DeadLetterFile != "":
+		f, err := deadletter.NewFileSink(cfg.Ingestion.DeadLetterFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		sink, closeSink = f, func() { f.Close() }
+	}
+
+	var opts []rating.Option
+	closeIngestion := closeSink
+	if cfg.Ingestion.File != "" {
+		fileOpts := []file.Option{file.WithCheckpoint(cfg.Ingestion.Checkpoint), file.WithFollow(cfg.Ingestion.Follow)}
+		if cfg.Ingestion.CheckpointInterval > 0 {
+			fileOpts = append(fileOpts, file.WithCheckpointInterval(cfg.Ingestion.CheckpointInterval))
+		}
+		if sink != nil {
+			fileOpts = append(fileOpts, file.WithDeadLetterSink(sink))
+		}
+		ingester := file.NewIngester(cfg.Ingestion.File, fileOpts...)
+		opts = append(opts, rating.WithIngester(ingester))
+		closeIngestion = func() {
+			if err := ingester.Close(); err != nil {
+				log.Printf("Failed to write the ingestion checkpoint: %v\n", err)
+			}
+			closeSink()
+		}
+	} else {
+		var kafkaOpts []kafka.Option
+		if sink != nil {
+			kafkaOpts = append(kafkaOpts, kafka.WithDeadLetterSink(sink))
+		}
+		ingester, err := kafka.NewIngester(cfg.Kafka.Broker, cfg.Kafka.GroupID, cfg.Kafka.Topic, kafkaOpts...)
+		if err != nil {
+			closeSink()
+			return nil, nil, err
+		}
+		opts = append(opts, rating.WithIngester(ingester))
+		closeIngestion = func() {
+			if err := ingester.Close(); err != nil {
+				log.Printf("Failed to close the rating consumer: %v\n", err)
+			}
+			closeSink()
+		}
+	}
+	if cfg.Ingestion.Retry.MaxAttempts > 0 {
+		opts = append(opts, rating.WithRetryPolicy(rating.RetryPolicy{
+			MaxAttempts: cfg.Ingestion.Retry.MaxAttempts,
+			Backoff:     cfg.Ingestion.Retry.Backoff,
+		}))
+	}
+	if cfg.Ingestion.Batch.Size > 1 {
+		opts = append(opts, rating.WithBatching(rating.BatchPolicy{
+			Size:     cfg.Ingestion.Batch.Size,
+			Interval: cfg.Ingestion.Batch.Interval,
+		}))
+	}
+	return opts, closeIngestion, nil
+}
+
+// outboxRelay returns the relay of the rating change events to Kafka if an outbox topic is set
+// or to in-process subscribers otherwise, and a function that closes the publisher.
+func outboxRelay(cfg serverConfig, store outbox.Store) (*outbox.Relay, func(), error) {
+	interval := cfg.Outbox.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	if cfg.Outbox.Topic == "" || cfg.Kafka.Broker == "" {
+		return outbox.NewRelay(store, outbox.NewLocalPublisher(), interval), func() {}, nil
+	}
+	publisher, err := outboxkafka.NewPublisher(cfg.Kafka.Broker, cfg.Outbox.Topic)
+	if err != nil {
+		return nil, nil, err
+	}
+	return outbox.NewRelay(store, publisher, interval), publisher.Close, nil
+}
+
 func setJaegerAsProvider(ctx context.Context, cfg serverConfig) {
 	tp, err := tracing.NewJaegerProvider(cfg.Jaeger.URL, serviceName)
 	if err != nil {
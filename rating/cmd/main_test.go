@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"movieexample.com/rating/internal/aggregation"
+	"movieexample.com/rating/internal/controller/rating"
+	"movieexample.com/rating/internal/repository/memory"
+	"movieexample.com/rating/pkg/model"
+)
+
+func TestAggregationOptions(t *testing.T) {
+	ctx := context.Background()
+	bayesian := func(cfg aggregationConfig) float64 {
+		opts, err := aggregationOptions(cfg)
+		require.NoError(t, err)
+		ctrl := rating.New(memory.New(), opts...)
+		require.NoError(t, ctrl.PutRating(ctx, "1", model.RecordTypeMovie, &model.Rating{UserID: "a", Value: 5}))
+		v, err := ctrl.GetAggregatedRating(ctx, "1", model.RecordTypeMovie, "")
+		require.NoError(t, err)
+		return v
+	}
+	builtin, _ := aggregation.Bayesian{PriorMean: 3, PriorWeight: 10}.Aggregate(ctx, staticAggregate{Sum: 5, Count: 1})
+
+	assert.InDelta(t, builtin, bayesian(aggregationConfig{Default: aggregation.NameBayesian}), 1e-9, "a missing section keeps the built-in parameters")
+	assert.InDelta(t, 4.0, bayesian(aggregationConfig{Default: aggregation.NameBayesian, Bayesian: &bayesianConfig{PriorMean: 3, PriorWeight: 1}}), 1e-9)
+
+	_, err := aggregationOptions(aggregationConfig{Default: "bayesain"})
+	assert.ErrorIs(t, err, rating.ErrUnknownStrategy)
+}
+
+// staticAggregate provides fixed running totals to a strategy.
+type staticAggregate model.Aggregate
+
+func (a staticAggregate) Aggregate(context.Context) (*model.Aggregate, error) {
+	agg := model.Aggregate(a)
+	return &agg, nil
+}
+
+func (a staticAggregate) Ratings(context.Context) ([]model.Rating, error) {
+	return nil, nil
+}
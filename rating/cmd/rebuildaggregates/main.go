@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"movieexample.com/rating/internal/controller/rating"
+	"movieexample.com/rating/internal/repository/mysql"
+)
+
+// rebuildaggregates recomputes the rating aggregates from the ratings table
+// for when the stored running totals drift from the individual ratings.
+func main() {
+	repo, err := mysql.New()
+	if err != nil {
+		panic(err)
+	}
+	ctrl := rating.New(repo)
+
+	log.Println("Rebuilding rating aggregates")
+	start := time.Now()
+	if err := ctrl.RebuildAggregates(context.Background()); err != nil {
+		log.Fatalf("rebuild aggregates: %v", err)
+	}
+	log.Printf("Rebuilt rating aggregates in %v", time.Since(start))
+}
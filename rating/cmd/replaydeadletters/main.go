@@ -0,0 +1,104 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"movieexample.com/rating/internal/ingester/deadletter"
+	ingesterkafka "movieexample.com/rating/internal/ingester/kafka"
+)
+
+// replaydeadletters publishes dead-lettered rating events back into the ratings topic,
+// either from a dead-letter topic or from the file of a dead-letter file sink.
+func main() {
+	broker := flag.String("broker", "localhost:9092", "Kafka broker address")
+	from := flag.String("from", "ratings-dlq", "dead-letter topic to replay")
+	file := flag.String("file", "", "dead-letter file to replay instead of a topic")
+	to := flag.String("to", "ratings", "topic to replay the rating events into")
+	groupID := flag.String("group", "rating-dlq-replay", "consumer group of the dead-letter topic")
+	idle := flag.Duration("idle", 5*time.Second, "stop reading the dead-letter topic after no message arrived for this long")
+	flag.Parse()
+
+	producer, err := kafka.NewProducer(&kafka.ConfigMap{"bootstrap.servers": *broker})
+	if err != nil {
+		panic(err)
+	}
+	defer producer.Close()
+
+	var n int
+	if *file != "" {
+		n, err = replayFile(producer, *file, *to)
+	} else {
+		n, err = replayTopic(producer, *broker, *groupID, *from, *to, *idle)
+	}
+	if err != nil {
+		log.Fatalf("replay dead letters: %v", err)
+	}
+	log.Printf("Replayed %d rating events into %s", n, *to)
+}
+
+func replayFile(producer *kafka.Producer, filename string, to string) (int, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	letters, err := deadletter.ReadFile(f)
+	if err != nil {
+		return 0, err
+	}
+	for i, l := range letters {
+		if err := replay(producer, to, l); err != nil {
+			return i, err
+		}
+	}
+	return len(letters), nil
+}
+
+func replayTopic(producer *kafka.Producer, broker, groupID, from, to string, idle time.Duration) (int, error) {
+	consumer, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers":  broker,
+		"group.id":           groupID,
+		"auto.offset.reset":  "earliest",
+		"enable.auto.commit": false,
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer consumer.Close()
+	if err := consumer.SubscribeTopics([]string{from}, nil); err != nil {
+		return 0, err
+	}
+	var n int
+	for {
+		msg, err := consumer.ReadMessage(idle)
+		if kerr, ok := err.(kafka.Error); ok && kerr.Code() == kafka.ErrTimedOut {
+			return n, nil
+		} else if err != nil {
+			return n, err
+		}
+		l := ingesterkafka.LetterFromMessage(msg, "")
+		log.Printf("Replaying %s[%d]@%d: %s", l.Topic, l.Partition, l.Offset, l.Reason)
+		if err := replay(producer, to, l); err != nil {
+			return n, err
+		}
+		if _, err := consumer.CommitMessage(msg); err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
+func replay(producer *kafka.Producer, to string, l deadletter.Letter) error {
+	delivery := make(chan kafka.Event, 1)
+	if err := producer.Produce(ingesterkafka.ReplayMessage(to, l), delivery); err != nil {
+		return err
+	}
+	if m, ok := (<-delivery).(*kafka.Message); ok && m.TopicPartition.Error != nil {
+		return m.TopicPartition.Error
+	}
+	return nil
+}
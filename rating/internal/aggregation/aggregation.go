@@ -0,0 +1,127 @@
+package aggregation
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"movieexample.com/rating/pkg/model"
+)
+
+// Names of the built-in strategies.
+const (
+	NameMean        = "mean"
+	NameBayesian    = "bayesian"
+	NameTimeDecay   = "time_decay"
+	NameTrimmedMean = "trimmed_mean"
+)
+
+// Source provides the ratings of a single record to a strategy.
+type Source interface {
+	// Aggregate returns the running totals of the record ratings.
+	Aggregate(ctx context.Context) (*model.Aggregate, error)
+	// Ratings returns all individual ratings of the record.
+	Ratings(ctx context.Context) ([]model.Rating, error)
+}
+
+// Strategy defines a way of aggregating the ratings of a record into a single value.
+type Strategy interface {
+	Aggregate(ctx context.Context, src Source) (float64, error)
+}
+
+// Mean is the plain average of all ratings.
+type Mean struct{}
+
+// Aggregate returns the average rating read from the running totals.
+func (Mean) Aggregate(ctx context.Context, src Source) (float64, error) {
+	agg, err := src.Aggregate(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return agg.Mean(), nil
+}
+
+// Bayesian is the average of all ratings pulled towards a prior mean.
+// Records with few ratings stay close to the prior, records with many
+// ratings converge to their plain average.
+type Bayesian struct {
+	// PriorMean is the rating assumed for a record without ratings.
+	PriorMean float64
+	// PriorWeight is the number of virtual ratings of PriorMean added to every record.
+	PriorWeight float64
+}
+
+// Aggregate returns the Bayesian average read from the running totals.
+func (b Bayesian) Aggregate(ctx context.Context, src Source) (float64, error) {
+	agg, err := src.Aggregate(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return (b.PriorWeight*b.PriorMean + float64(agg.Sum)) / (b.PriorWeight + float64(agg.Count)), nil
+}
+
+// TimeDecay is the average of all ratings weighted by their age,
+// so the weight of a rating halves every HalfLife.
+type TimeDecay struct {
+	HalfLife time.Duration
+	// Now returns the current time, time.Now is used if it is nil.
+	Now func() time.Time
+}
+
+// Aggregate returns the time-decayed average of the individual ratings.
+func (d TimeDecay) Aggregate(ctx context.Context, src Source) (float64, error) {
+	ratings, err := src.Ratings(ctx)
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now()
+	if d.Now != nil {
+		now = d.Now()
+	}
+	var sum, weights float64
+	for _, r := range ratings {
+		w := 1.0
+		if age := now.Sub(r.Timestamp); d.HalfLife > 0 && age > 0 {
+			w = math.Exp2(-float64(age) / float64(d.HalfLife))
+		}
+		sum += w * float64(r.Value)
+		weights += w
+	}
+	if weights == 0 {
+		return 0, nil
+	}
+	return sum / weights, nil
+}
+
+// TrimmedMean is the average of all ratings after discarding
+// the Fraction of lowest and the Fraction of highest ratings.
+type TrimmedMean struct {
+	Fraction float64
+}
+
+// Aggregate returns the trimmed mean of the individual ratings.
+func (t TrimmedMean) Aggregate(ctx context.Context, src Source) (float64, error) {
+	ratings, err := src.Ratings(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(ratings) == 0 {
+		return 0, nil
+	}
+	values := make([]float64, 0, len(ratings))
+	for _, r := range ratings {
+		values = append(values, float64(r.Value))
+	}
+	sort.Float64s(values)
+	k := int(float64(len(values)) * t.Fraction)
+	if 2*k >= len(values) {
+		k = (len(values) - 1) / 2
+	}
+	values = values[k : len(values)-k]
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values)), nil
+}
@@ -0,0 +1,52 @@
+package aggregation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"movieexample.com/rating/pkg/model"
+)
+
+type staticSource []model.Rating
+
+func (s staticSource) Aggregate(_ context.Context) (*model.Aggregate, error) {
+	var agg model.Aggregate
+	for _, r := range s {
+		agg.Sum += int64(r.Value)
+		agg.Count++
+	}
+	return &agg, nil
+}
+
+func (s staticSource) Ratings(_ context.Context) ([]model.Rating, error) {
+	return s, nil
+}
+
+func TestStrategies(t *testing.T) {
+	now := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	src := staticSource{
+		{Value: 1, Timestamp: now.Add(-48 * time.Hour)},
+		{Value: 4, Timestamp: now.Add(-24 * time.Hour)},
+		{Value: 4, Timestamp: now},
+		{Value: 5, Timestamp: now},
+	}
+	tests := []struct {
+		name     string
+		strategy Strategy
+		want     float64
+	}{
+		{name: "mean", strategy: Mean{}, want: 3.5},
+		{name: "bayesian", strategy: Bayesian{PriorMean: 3, PriorWeight: 4}, want: 3.25},
+		{name: "time decay", strategy: TimeDecay{HalfLife: 24 * time.Hour, Now: func() time.Time { return now }}, want: (0.25*1 + 0.5*4 + 4 + 5) / 2.75},
+		{name: "trimmed mean", strategy: TrimmedMean{Fraction: 0.25}, want: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.strategy.Aggregate(context.Background(), src)
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package rating
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"movieexample.com/rating/internal/ingester"
+	"movieexample.com/rating/pkg/model"
+)
+
+// errIngestionStopped is returned when the ingestion context is cancelled while a batch is written.
+var errIngestionStopped = errors.New("ingestion stopped")
+
+// BatchPolicy defines how ingested rating events are written in batches. A batch is flushed
+// once it holds Size events or Interval after its first event, whichever comes first.
+type BatchPolicy struct {
+	Size     int
+	Interval time.Duration
+}
+
+// WithBatching makes StartIngestion write rating events in batches. Deliveries are not read
+// while a batch is written, so a slow repository slows down the consumption of the ingester.
+func WithBatching(p BatchPolicy) Option {
+	return func(c *Controller) {
+		c.batch = p
+	}
+}
+
+// ingestBatches buffers the deliveries of an ingester and writes them in batches.
+func (c *Controller) ingestBatches(ctx context.Context, writeCtx context.Context, ch chan ingester.Delivery) error {
+	var pending []ingester.Delivery
+	var deadline <-chan time.Time
+	flush := func() error {
+		err := c.flush(ctx, writeCtx, pending)
+		pending, deadline = nil, nil
+		return err
+	}
+	for {
+		select {
+		case d, ok := <-ch:
+			if !ok {
+				return ignoreStopped(flush())
+			}
+			pending = append(pending, d)
+			if len(pending) == 1 && c.batch.Interval > 0 {
+				deadline = time.After(c.batch.Interval)
+			}
+			if len(pending) >= c.batch.Size {
+				if err := flush(); err != nil {
+					return ignoreStopped(err)
+				}
+			}
+		case <-deadline:
+			if err := flush(); err != nil {
+				return ignoreStopped(err)
+			}
+		}
+	}
+}
+
+// flush writes a batch of deliveries and then commits or rejects each of them in delivery order.
+// Consecutive puts are written with a single bulk put, other events are applied one by one in between.
+// If a bulk put keeps failing its events are applied one by one so that only the failing ones are rejected.
+func (c *Controller) flush(ctx context.Context, writeCtx context.Context, deliveries []ingester.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	outcomes := make([]error, len(deliveries))
+	var puts []model.Rating
+	var putIDs []string
+	var putIndexes []int
+	writePuts := func() error {
+		if len(puts) == 0 {
+			return nil
+		}
+		err := c.withRetries(ctx, func() error {
+			return c.repo.PutBatch(writeCtx, puts, putIDs)
+		})
+		if err != nil && ctx.Err() != nil {
+			return errIngestionStopped
+		} else if err != nil {
+			log.Printf("Bulk put of %d ratings failed, applying them one by one: %v\n", len(puts), err)
+			for _, i := range putIndexes {
+				if outcomes[i] = c.handleEventWithRetries(ctx, writeCtx, deliveries[i].Event); outcomes[i] != nil && ctx.Err() != nil {
+					return errIngestionStopped
+				}
+			}
+		}
+		puts, putIDs, putIndexes = nil, nil, nil
+		return nil
+	}
+	for i, d := range deliveries {
+		switch {
+		case d.Err != nil:
+			outcomes[i] = d.Err
+		case d.Event.EventType == model.RatingEventTypePut:
+			rating := model.Rating{RecordID: d.Event.RecordID, RecordType: d.Event.RecordType, UserID: d.Event.UserID, Value: d.Event.Value, Timestamp: d.Event.Timestamp}
+			if err := c.prepareRating(rating.RecordType, &rating); err != nil {
+				outcomes[i] = err
+				continue
+			}
+			puts = append(puts, rating)
+			putIDs = append(putIDs, d.Event.ID)
+			putIndexes = append(putIndexes, i)
+		default:
+			if err := writePuts(); err != nil {
+				return err
+			}
+			if outcomes[i] = c.handleEventWithRetries(ctx, writeCtx, d.Event); outcomes[i] != nil && ctx.Err() != nil {
+				return errIngestionStopped
+			}
+		}
+	}
+	if err := writePuts(); err != nil {
+		return err
+	}
+	for i, d := range deliveries {
+		if outcomes[i] != nil {
+			if err := reject(d, outcomes[i]); err != nil {
+				return err
+			}
+		} else if err := d.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ignoreStopped turns the cancellation of a batch into a regular end of ingestion.
+// The events of the cancelled batch are left uncommitted and are delivered again after a restart.
+func ignoreStopped(err error) error {
+	if errors.Is(err, errIngestionStopped) {
+		return nil
+	}
+	return err
+}
@@ -2,7 +2,15 @@ package rating
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"movieexample.com/rating/internal/aggregation"
+	"movieexample.com/rating/internal/ingester"
 	"movieexample.com/rating/internal/repository"
 	model "movieexample.com/rating/pkg/model"
 )
@@ -10,62 +18,398 @@ import (
 // ErrNotFound is returned when no ratings are found for a record.
 var ErrNotFound = errors.New("ratings not found for a record")
 
+// ErrUnknownStrategy is returned when a request names an aggregation strategy that is not registered.
+var ErrUnknownStrategy = errors.New("unknown aggregation strategy")
+
+// ErrInvalidArgument is returned when a request contains malformed parameters.
+var ErrInvalidArgument = errors.New("invalid argument")
+
+// ErrNoIngester is returned when ingestion is started on a controller without an ingester.
+var ErrNoIngester = errors.New("no rating ingester configured")
+
+// Page size limits of rating listings.
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// MaxBatchSize is the maximum number of records in a batch request.
+const MaxBatchSize = 100
+
 type ratingRepository interface {
 	Get(ctx context.Context, recordID model.RecordID, recordType model.RecordType) ([]model.Rating, error)
-	Put(ctx context.Context, recordID model.RecordID, recordType model.RecordType, rating *model.Rating) error
+	Put(ctx context.Context, recordID model.RecordID, recordType model.RecordType, rating *model.Rating, eventID string) error
+	PutBatch(ctx context.Context, ratings []model.Rating, eventIDs []string) error
+	Delete(ctx context.Context, recordID model.RecordID, recordType model.RecordType, userID model.UserID, eventID string) error
+	GetAggregate(ctx context.Context, recordID model.RecordID, recordType model.RecordType) (*model.Aggregate, error)
+	GetAggregates(ctx context.Context, recordIDs []model.RecordID, recordType model.RecordType) (map[model.RecordID]*model.Aggregate, error)
+	ListByUser(ctx context.Context, userID model.UserID, opts model.UserRatingsOptions) ([]model.Rating, error)
+	GetTopRated(ctx context.Context, q model.TopRatedQuery) ([]model.RankedRecord, error)
+	GetStats(ctx context.Context, recordID model.RecordID, recordType model.RecordType) (*model.RatingStats, error)
+	RebuildAggregates(ctx context.Context) error
+}
+
+// RetryPolicy defines how an ingested rating event that fails to persist is retried
+// before it is rejected. The backoff doubles after every attempt.
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
 }
 
 type Controller struct {
-	repo     ratingRepository
-	ingester ratingIngester
+	repo            ratingRepository
+	ingester        ratingIngester
+	retry           RetryPolicy
+	batch           BatchPolicy
+	strategies      map[string]aggregation.Strategy
+	defaultStrategy string
+	scales          model.ScaleRegistry
+}
+
+// Option configures a rating service controller.
+type Option func(*Controller)
+
+// WithStrategy registers an aggregation strategy under a name, replacing a built-in strategy of the same name.
+func WithStrategy(name string, s aggregation.Strategy) Option {
+	return func(c *Controller) {
+		c.strategies[name] = s
+	}
+}
+
+// WithDefaultStrategy sets the aggregation strategy used when a request names none.
+func WithDefaultStrategy(name string) Option {
+	return func(c *Controller) {
+		c.defaultStrategy = name
+	}
+}
+
+// WithScales sets the rating scales put ratings are validated against.
+func WithScales(scales model.ScaleRegistry) Option {
+	return func(c *Controller) {
+		c.scales = scales
+	}
+}
+
+// WithIngester sets the source of rating events consumed by StartIngestion.
+func WithIngester(ingester ratingIngester) Option {
+	return func(c *Controller) {
+		c.ingester = ingester
+	}
+}
+
+// WithRetryPolicy sets the retry policy of ingested rating events.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Controller) {
+		c.retry = p
+	}
 }
 
 // New creates a rating service controller.
-func New(repo ratingRepository) *Controller {
-	return &Controller{
+func New(repo ratingRepository, opts ...Option) *Controller {
+	c := &Controller{
 		repo: repo,
+		strategies: map[string]aggregation.Strategy{
+			aggregation.NameMean:        aggregation.Mean{},
+			aggregation.NameBayesian:    aggregation.Bayesian{PriorMean: 3, PriorWeight: 10},
+			aggregation.NameTimeDecay:   aggregation.TimeDecay{HalfLife: 30 * 24 * time.Hour},
+			aggregation.NameTrimmedMean: aggregation.TrimmedMean{Fraction: 0.1},
+		},
+		defaultStrategy: aggregation.NameMean,
+		scales:          model.DefaultScales(),
+		retry:           RetryPolicy{MaxAttempts: 3, Backoff: 100 * time.Millisecond},
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 // GetAggregatedRating returns the aggregated rating for a record or ErrNotFound if there are no ratings for it.
-func (c *Controller) GetAggregatedRating(ctx context.Context, recordID model.RecordID, recordType model.RecordType) (float64, error) {
-	ratings, err := c.repo.Get(ctx, recordID, recordType)
+// The ratings are aggregated with the named strategy or with the default strategy if the name is empty.
+func (c *Controller) GetAggregatedRating(ctx context.Context, recordID model.RecordID, recordType model.RecordType, strategy string) (float64, error) {
+	if strategy == "" {
+		strategy = c.defaultStrategy
+	}
+	s, ok := c.strategies[strategy]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownStrategy, strategy)
+	}
+	v, err := s.Aggregate(ctx, &recordSource{repo: c.repo, recordID: recordID, recordType: recordType})
 	if err != nil && errors.Is(err, repository.ErrNotFound) {
 		return 0, ErrNotFound
 	} else if err != nil {
 		return 0, err
 	}
+	return v, nil
+}
+
+// BatchGetAggregatedRating returns the aggregated ratings of several records of a type keyed by record id.
+// The running totals of all records are fetched in a single query. Records without ratings are absent from the result.
+func (c *Controller) BatchGetAggregatedRating(ctx context.Context, recordIDs []model.RecordID, recordType model.RecordType, strategy string) (map[model.RecordID]float64, error) {
+	if len(recordIDs) > MaxBatchSize {
+		return nil, fmt.Errorf("%w: more than %d records", ErrInvalidArgument, MaxBatchSize)
+	}
+	if strategy == "" {
+		strategy = c.defaultStrategy
+	}
+	s, ok := c.strategies[strategy]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownStrategy, strategy)
+	}
+	aggs, err := c.repo.GetAggregates(ctx, recordIDs, recordType)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[model.RecordID]float64, len(aggs))
+	for id, agg := range aggs {
+		v, err := s.Aggregate(ctx, &recordSource{repo: c.repo, recordID: id, recordType: recordType, aggregate: agg})
+		if err != nil && errors.Is(err, repository.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		res[id] = v
+	}
+	return res, nil
+}
+
+// recordSource provides the ratings of a record to aggregation strategies.
+// The running totals are read from the repository unless they were prefetched.
+type recordSource struct {
+	repo       ratingRepository
+	recordID   model.RecordID
+	recordType model.RecordType
+	aggregate  *model.Aggregate
+}
+
+func (s *recordSource) Aggregate(ctx context.Context) (*model.Aggregate, error) {
+	if s.aggregate != nil {
+		return s.aggregate, nil
+	}
+	return s.repo.GetAggregate(ctx, s.recordID, s.recordType)
+}
+
+func (s *recordSource) Ratings(ctx context.Context) ([]model.Rating, error) {
+	return s.repo.Get(ctx, s.recordID, s.recordType)
+}
+
+// GetRatingStats returns the rating distribution of a record or ErrNotFound if there are no ratings for it.
+func (c *Controller) GetRatingStats(ctx context.Context, recordID model.RecordID, recordType model.RecordType) (*model.RatingStats, error) {
+	stats, err := c.repo.GetStats(ctx, recordID, recordType)
+	if err != nil && errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// ListUserRatings returns a page of the ratings given by a user, newest first unless oldestFirst is set,
+// together with the token of the next page. The next page token is empty when there are no more ratings.
+func (c *Controller) ListUserRatings(ctx context.Context, userID model.UserID, recordType model.RecordType, pageSize int, pageToken string, oldestFirst bool) ([]model.Rating, string, error) {
+	if pageSize < 0 {
+		return nil, "", fmt.Errorf("%w: negative page size", ErrInvalidArgument)
+	} else if pageSize == 0 {
+		pageSize = DefaultPageSize
+	} else if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+	opts := model.UserRatingsOptions{RecordType: recordType, Limit: pageSize + 1, OldestFirst: oldestFirst}
+	if pageToken != "" {
+		cursor, err := decodePageToken(pageToken)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: malformed page token", ErrInvalidArgument)
+		}
+		opts.After = cursor
+	}
+	res, err := c.repo.ListByUser(ctx, userID, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(res) <= pageSize {
+		return res, "", nil
+	}
+	res = res[:pageSize]
+	next, err := encodePageToken(model.CursorOf(&res[pageSize-1]))
+	if err != nil {
+		return nil, "", err
+	}
+	return res, next, nil
+}
 
-	sum := float64(0)
-	for _, rating := range ratings {
-		sum += float64(rating.Value)
+func encodePageToken(c *model.RatingCursor) (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
 	}
-	return sum / float64(len(ratings)), nil
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
 
+func decodePageToken(token string) (*model.RatingCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	var c model.RatingCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
 }
 
-// PutRating writes a rating for a given record.
+// GetTopRated returns the best rated records of a type from the maintained leaderboard.
+func (c *Controller) GetTopRated(ctx context.Context, q model.TopRatedQuery) ([]model.RankedRecord, error) {
+	if q.RecordType == "" {
+		return nil, fmt.Errorf("%w: empty record type", ErrInvalidArgument)
+	}
+	if q.MinVotes < 0 || q.Window < 0 {
+		return nil, fmt.Errorf("%w: negative min votes or window", ErrInvalidArgument)
+	}
+	if q.Limit < 0 {
+		return nil, fmt.Errorf("%w: negative limit", ErrInvalidArgument)
+	} else if q.Limit == 0 {
+		q.Limit = DefaultPageSize
+	} else if q.Limit > MaxPageSize {
+		q.Limit = MaxPageSize
+	}
+	return c.repo.GetTopRated(ctx, q)
+}
+
+// RebuildAggregates recomputes the stored rating aggregates of all records from individual ratings.
+func (c *Controller) RebuildAggregates(ctx context.Context) error {
+	return c.repo.RebuildAggregates(ctx)
+}
+
+// PutRating writes a rating for a given record, replacing an earlier rating of the same user.
+// A rating without a timestamp is stamped with the current time. It returns ErrInvalidArgument
+// if the rating value is not allowed by the scale of the record type.
 func (c *Controller) PutRating(ctx context.Context, recordID model.RecordID, recordType model.RecordType, rating *model.Rating) error {
-	return c.repo.Put(ctx, recordID, recordType, rating)
+	if err := c.prepareRating(recordType, rating); err != nil {
+		return err
+	}
+	return c.repo.Put(ctx, recordID, recordType, rating, "")
+}
+
+// prepareRating validates a rating against the scale of its record type and stamps it if it has no timestamp.
+func (c *Controller) prepareRating(recordType model.RecordType, rating *model.Rating) error {
+	if err := c.scales.Validate(recordType, rating.Value); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgument, err)
+	}
+	if rating.Timestamp.IsZero() {
+		rating.Timestamp = time.Now().UTC()
+	}
+	return nil
+}
+
+// DeleteRating removes the rating of a user for a given record or returns ErrNotFound if there is none.
+func (c *Controller) DeleteRating(ctx context.Context, recordID model.RecordID, recordType model.RecordType, userID model.UserID) error {
+	if err := c.repo.Delete(ctx, recordID, recordType, userID, ""); err != nil && errors.Is(err, repository.ErrNotFound) {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+	return nil
 }
 
 type ratingIngester interface {
-	Ingest(ctx context.Context) (chan model.RatingEvent, error)
+	Ingest(ctx context.Context) (chan ingester.Delivery, error)
 }
 
-// StartIngestion starts the ingestion of rating events.
+// StartIngestion starts the ingestion of rating events and blocks until the ingester
+// stops delivering them, which happens once the context is cancelled. An event that is
+// already being applied when the context is cancelled is still written. Every event is
+// committed to the ingester only after it has been persisted. Invalid events and events
+// that still fail after the retries of the retry policy are rejected to the dead-letter sink.
 func (c *Controller) StartIngestion(ctx context.Context) error {
+	if c.ingester == nil {
+		return ErrNoIngester
+	}
 	ch, err := c.ingester.Ingest(ctx)
 	if err != nil {
 		return err
 	}
-	for e := range ch {
-		if err := c.PutRating(ctx, e.RecordID, e.RecordType, &model.Rating{
-			UserID: e.UserID,
-			Value:  e.Value,
-		}); err != nil {
+	writeCtx := context.WithoutCancel(ctx)
+	if c.batch.Size > 1 {
+		return c.ingestBatches(ctx, writeCtx, ch)
+	}
+	for d := range ch {
+		if d.Err != nil {
+			if err := reject(d, d.Err); err != nil {
+				return err
+			}
+			continue
+		}
+		err := c.handleEventWithRetries(ctx, writeCtx, d.Event)
+		if err != nil && ctx.Err() != nil {
+			// Leave the event uncommitted so that it is delivered again after a restart.
+			return nil
+		} else if err != nil {
+			if err := reject(d, err); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := d.Commit(); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+func reject(d ingester.Delivery, reason error) error {
+	log.Printf("Rejecting rating event %+v: %v\n", d.Event, reason)
+	return d.Reject(reason)
+}
+
+// handleEventWithRetries applies a rating event, retrying failures other than invalid arguments
+// until the retry policy is exhausted or the ingestion context is cancelled.
+func (c *Controller) handleEventWithRetries(ctx context.Context, writeCtx context.Context, e model.RatingEvent) error {
+	return c.withRetries(ctx, func() error {
+		return c.handleEvent(writeCtx, e)
+	})
+}
+
+// withRetries calls fn until it succeeds, fails with an invalid argument, the retry policy is
+// exhausted or the ingestion context is cancelled.
+func (c *Controller) withRetries(ctx context.Context, fn func() error) error {
+	backoff := c.retry.Backoff
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || errors.Is(err, ErrInvalidArgument) || attempt >= c.retry.MaxAttempts {
+			return err
+		}
+		log.Printf("Rating write attempt %d failed, retrying in %v: %v\n", attempt, backoff, err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// handleEvent applies a single rating event unless an event with the same id has already been applied.
+// The id of the event is recorded in the same write as the rating, so a failed write is applied again
+// on redelivery and a successful one is not. Deleting a rating that does not exist is a no-op.
+func (c *Controller) handleEvent(ctx context.Context, e model.RatingEvent) error {
+	switch e.EventType {
+	case model.RatingEventTypePut:
+		rating := &model.Rating{
+			UserID:    e.UserID,
+			Value:     e.Value,
+			Timestamp: e.Timestamp,
+		}
+		if err := c.prepareRating(e.RecordType, rating); err != nil {
+			return err
+		}
+		return c.repo.Put(ctx, e.RecordID, e.RecordType, rating, e.ID)
+	case model.RatingEventTypeDelete:
+		if err := c.repo.Delete(ctx, e.RecordID, e.RecordType, e.UserID, e.ID); err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+		return nil
+	default:
+		log.Printf("Skipping rating event of unknown type %q\n", e.EventType)
+		return nil
+	}
+}
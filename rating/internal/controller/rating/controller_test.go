@@ -0,0 +1,278 @@
+package rating
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"movieexample.com/rating/internal/ingester"
+	"movieexample.com/rating/internal/repository/memory"
+	"movieexample.com/rating/pkg/model"
+)
+
+// fakeIngester delivers a fixed list of events and counts the commits and rejections.
+type fakeIngester struct {
+	events    []model.RatingEvent
+	committed int
+	rejected  []error
+}
+
+func (i *fakeIngester) Ingest(ctx context.Context) (chan ingester.Delivery, error) {
+	ch := make(chan ingester.Delivery, len(i.events))
+	for _, e := range i.events {
+		ch <- ingester.Delivery{
+			Event: e,
+			Commit: func() error {
+				i.committed++
+				return nil
+			},
+			Reject: func(reason error) error {
+				i.rejected = append(i.rejected, reason)
+				return nil
+			},
+		}
+	}
+	close(ch)
+	return ch, nil
+}
+
+func TestStartIngestionIsIdempotent(t *testing.T) {
+	put := model.RatingEvent{ID: "e1", UserID: "a", RecordID: "1", RecordType: model.RecordTypeMovie, Value: 4, EventType: model.RatingEventTypePut}
+	del := model.RatingEvent{ID: "e2", UserID: "a", RecordID: "1", RecordType: model.RecordTypeMovie, EventType: model.RatingEventTypeDelete}
+	invalid := model.RatingEvent{ID: "e3", UserID: "b", RecordID: "1", RecordType: model.RecordTypeMovie, Value: 9, EventType: model.RatingEventTypePut}
+	in := &fakeIngester{events: []model.RatingEvent{put, del, put, invalid}}
+	ctrl := New(memory.New(), WithIngester(in))
+
+	assert.NoError(t, ctrl.StartIngestion(context.Background()))
+	_, err := ctrl.GetAggregatedRating(context.Background(), "1", model.RecordTypeMovie, "")
+	assert.ErrorIs(t, err, ErrNotFound, "a redelivered put must not undo a later delete")
+	assert.Equal(t, 3, in.committed)
+	if assert.Len(t, in.rejected, 1) {
+		assert.ErrorIs(t, in.rejected[0], ErrInvalidArgument)
+	}
+}
+
+// flakyRepository fails the first puts with a transient error.
+type flakyRepository struct {
+	*memory.Repository
+	failures int
+}
+
+func (r *flakyRepository) Put(ctx context.Context, recordID model.RecordID, recordType model.RecordType, rating *model.Rating, eventID string) error {
+	if r.failures > 0 {
+		r.failures--
+		return errors.New("connection reset")
+	}
+	return r.Repository.Put(ctx, recordID, recordType, rating, eventID)
+}
+
+func TestStartIngestionRetries(t *testing.T) {
+	put := model.RatingEvent{UserID: "a", RecordID: "1", RecordType: model.RecordTypeMovie, Value: 4, EventType: model.RatingEventTypePut}
+	in := &fakeIngester{events: []model.RatingEvent{put, put}}
+	repo := &flakyRepository{Repository: memory.New(), failures: 4}
+	ctrl := New(repo, WithIngester(in), WithRetryPolicy(RetryPolicy{MaxAttempts: 3}))
+
+	assert.NoError(t, ctrl.StartIngestion(context.Background()))
+	assert.Len(t, in.rejected, 1, "the first event exhausts its attempts")
+	assert.Equal(t, 1, in.committed, "the second event succeeds on its second attempt")
+}
+
+func TestStartIngestionRedeliversFailedEvents(t *testing.T) {
+	put := model.RatingEvent{ID: "e1", UserID: "a", RecordID: "1", RecordType: model.RecordTypeMovie, Value: 4, EventType: model.RatingEventTypePut}
+	in := &fakeIngester{events: []model.RatingEvent{put, put}}
+	repo := &flakyRepository{Repository: memory.New(), failures: 1}
+	ctrl := New(repo, WithIngester(in), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+
+	assert.NoError(t, ctrl.StartIngestion(context.Background()))
+	assert.Len(t, in.rejected, 1)
+	assert.Equal(t, 1, in.committed, "a failed write does not mark its event as processed")
+	agg, err := repo.GetAggregate(context.Background(), "1", model.RecordTypeMovie)
+	assert.NoError(t, err)
+	assert.Equal(t, &model.Aggregate{Sum: 4, Count: 1}, agg)
+}
+
+func TestStartIngestionBatches(t *testing.T) {
+	event := func(id string, user model.UserID, value model.RatingValue, typ model.RatingEventType) model.RatingEvent {
+		return model.RatingEvent{ID: id, UserID: user, RecordID: "1", RecordType: model.RecordTypeMovie, Value: value, EventType: typ}
+	}
+	in := &fakeIngester{events: []model.RatingEvent{
+		event("e1", "a", 5, model.RatingEventTypePut),
+		event("e2", "b", 3, model.RatingEventTypePut),
+		event("e3", "a", 0, model.RatingEventTypeDelete),
+		event("e4", "c", 7, model.RatingEventTypePut),
+		event("e1", "a", 5, model.RatingEventTypePut),
+		event("e5", "b", 1, model.RatingEventTypePut),
+	}}
+	repo := memory.New()
+	ctrl := New(repo, WithIngester(in), WithBatching(BatchPolicy{Size: 4}))
+
+	assert.NoError(t, ctrl.StartIngestion(context.Background()))
+	agg, err := repo.GetAggregate(context.Background(), "1", model.RecordTypeMovie)
+	assert.NoError(t, err)
+	assert.Equal(t, &model.Aggregate{Sum: 1, Count: 1}, agg, "only the latest rating of b remains")
+	assert.Equal(t, 5, in.committed)
+	assert.Len(t, in.rejected, 1)
+}
+
+func TestPutAndDeleteRating(t *testing.T) {
+	ctx := context.Background()
+	ctrl := New(memory.New())
+	assert.NoError(t, ctrl.PutRating(ctx, "1", model.RecordTypeMovie, &model.Rating{UserID: "a", Value: 5}))
+	assert.NoError(t, ctrl.PutRating(ctx, "1", model.RecordTypeMovie, &model.Rating{UserID: "b", Value: 3}))
+	assert.NoError(t, ctrl.PutRating(ctx, "1", model.RecordTypeMovie, &model.Rating{UserID: "a", Value: 1}))
+	v, err := ctrl.GetAggregatedRating(ctx, "1", model.RecordTypeMovie, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 2.0, v, "the second rating of a replaces the first one")
+
+	assert.NoError(t, ctrl.DeleteRating(ctx, "1", model.RecordTypeMovie, "b"))
+	v, _ = ctrl.GetAggregatedRating(ctx, "1", model.RecordTypeMovie, "")
+	assert.Equal(t, 1.0, v)
+	assert.ErrorIs(t, ctrl.DeleteRating(ctx, "1", model.RecordTypeMovie, "b"), ErrNotFound)
+
+	assert.NoError(t, ctrl.DeleteRating(ctx, "1", model.RecordTypeMovie, "a"))
+	_, err = ctrl.GetAggregatedRating(ctx, "1", model.RecordTypeMovie, "")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestStartIngestionAppliesDeletes(t *testing.T) {
+	event := func(user model.UserID, value model.RatingValue, typ model.RatingEventType) model.RatingEvent {
+		return model.RatingEvent{UserID: user, RecordID: "1", RecordType: model.RecordTypeMovie, Value: value, EventType: typ}
+	}
+	in := &fakeIngester{events: []model.RatingEvent{
+		event("a", 5, model.RatingEventTypePut),
+		event("b", 3, model.RatingEventTypePut),
+		event("a", 0, model.RatingEventTypeDelete),
+		event("c", 0, model.RatingEventTypeDelete),
+		event("b", 4, model.RatingEventTypePut),
+	}}
+	repo := memory.New()
+	ctrl := New(repo, WithIngester(in))
+
+	assert.NoError(t, ctrl.StartIngestion(context.Background()))
+	res, err := repo.Get(context.Background(), "1", model.RecordTypeMovie)
+	assert.NoError(t, err)
+	if assert.Len(t, res, 1) {
+		assert.Equal(t, model.UserID("b"), res[0].UserID)
+		assert.Equal(t, model.RatingValue(4), res[0].Value)
+	}
+	assert.Equal(t, 5, in.committed, "deleting a missing rating is not an error")
+	assert.Empty(t, in.rejected)
+}
+
+// streamIngester delivers events sent to it until the ingestion context is cancelled.
+// Commits wait for release when it is set and are recorded along with Close.
+type streamIngester struct {
+	events    chan model.RatingEvent
+	committed chan string
+	release   chan struct{}
+	err       error
+
+	mu    sync.Mutex
+	calls []string
+}
+
+func (i *streamIngester) record(call string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.calls = append(i.calls, call)
+}
+
+func (i *streamIngester) Close() error {
+	i.record("close")
+	return nil
+}
+
+func (i *streamIngester) Ingest(ctx context.Context) (chan ingester.Delivery, error) {
+	if i.err != nil {
+		return nil, i.err
+	}
+	ch := make(chan ingester.Delivery)
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case e := <-i.events:
+				d := ingester.Delivery{
+					Event: e,
+					Commit: func() error {
+						i.committed <- e.ID
+						if i.release != nil {
+							<-i.release
+						}
+						i.record("commit " + e.ID)
+						return nil
+					},
+					Reject: func(error) error { return nil },
+				}
+				select {
+				case ch <- d:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func TestStartIngestionLifecycle(t *testing.T) {
+	assert.ErrorIs(t, New(memory.New()).StartIngestion(context.Background()), ErrNoIngester)
+
+	failing := &streamIngester{err: errors.New("broker unreachable")}
+	assert.EqualError(t, New(memory.New(), WithIngester(failing)).StartIngestion(context.Background()), "broker unreachable")
+
+	closed := &fakeIngester{}
+	assert.NoError(t, New(memory.New(), WithIngester(closed)).StartIngestion(context.Background()), "a closed channel ends the ingestion")
+
+	in := &streamIngester{events: make(chan model.RatingEvent), committed: make(chan string, 1), release: make(chan struct{})}
+	repo := memory.New()
+	ctrl := New(repo, WithIngester(in))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- ctrl.StartIngestion(ctx)
+	}()
+	in.events <- model.RatingEvent{ID: "e1", UserID: "a", RecordID: "1", RecordType: model.RecordTypeMovie, Value: 4, EventType: model.RatingEventTypePut}
+	assert.Equal(t, "e1", <-in.committed)
+	cancel()
+	select {
+	case <-done:
+		t.Fatal("ingestion stopped before the in-flight delivery was committed")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(in.release)
+	select {
+	case err := <-done:
+		assert.NoError(t, err, "cancellation stops the ingestion without an error")
+	case <-time.After(time.Second):
+		t.Fatal("ingestion did not stop after cancellation")
+	}
+	// The rating service closes the ingester once StartIngestion returns.
+	assert.NoError(t, in.Close())
+	assert.Equal(t, []string{"commit e1", "close"}, in.calls, "the consumer is closed after the last commit")
+	_, err := repo.GetAggregate(context.Background(), "1", model.RecordTypeMovie)
+	assert.NoError(t, err)
+}
+
+func TestBatchGetAggregatedRating(t *testing.T) {
+	ctx := context.Background()
+	ctrl := New(memory.New())
+	assert.NoError(t, ctrl.PutRating(ctx, "1", model.RecordTypeMovie, &model.Rating{UserID: "a", Value: 5}))
+	assert.NoError(t, ctrl.PutRating(ctx, "1", model.RecordTypeMovie, &model.Rating{UserID: "b", Value: 2}))
+	assert.NoError(t, ctrl.PutRating(ctx, "2", model.RecordTypeMovie, &model.Rating{UserID: "a", Value: 4}))
+	assert.NoError(t, ctrl.PutRating(ctx, "3", model.RecordTypeEpisode, &model.Rating{UserID: "a", Value: 9}))
+
+	res, err := ctrl.BatchGetAggregatedRating(ctx, []model.RecordID{"1", "2", "3", "1"}, model.RecordTypeMovie, "")
+	assert.NoError(t, err)
+	assert.Equal(t, map[model.RecordID]float64{"1": 3.5, "2": 4}, res, "records without ratings of the type are absent")
+
+	_, err = ctrl.BatchGetAggregatedRating(ctx, []model.RecordID{"1"}, model.RecordTypeMovie, "median")
+	assert.ErrorIs(t, err, ErrUnknownStrategy)
+	_, err = ctrl.BatchGetAggregatedRating(ctx, make([]model.RecordID, MaxBatchSize+1), model.RecordTypeMovie, "")
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
@@ -6,6 +6,7 @@ import (
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"movieexample.com/gen"
 	"movieexample.com/rating/internal/controller/rating"
 	"movieexample.com/rating/pkg/model"
@@ -25,22 +26,133 @@ func (h *Handler) GetAggregatedRating(ctx context.Context, req *gen.GetAggregate
 	if req == nil || req.Id == "" || req.Type == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "nil req or empty id")
 	}
-	v, err := h.ctrl.GetAggregatedRating(ctx, model.RecordID(req.Id), model.RecordType(req.Type))
+	v, err := h.ctrl.GetAggregatedRating(ctx, model.RecordID(req.Id), model.RecordType(req.Type), req.Strategy)
 	if err != nil && errors.Is(err, rating.ErrNotFound) {
 		return nil, status.Errorf(codes.NotFound, err.Error())
+	} else if err != nil && errors.Is(err, rating.ErrUnknownStrategy) {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
 	} else if err != nil {
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
 	return &gen.GetAggregatedRatingResponse{Rating: v}, nil
 }
 
+// BatchGetAggregatedRating returns the aggregated ratings of several records.
+// Records without ratings get a NotFound item error instead of failing the request.
+func (h *Handler) BatchGetAggregatedRating(ctx context.Context, req *gen.BatchGetAggregatedRatingRequest) (*gen.BatchGetAggregatedRatingResponse, error) {
+	if req == nil || req.Type == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "nil req or empty type")
+	}
+	ids := make([]model.RecordID, 0, len(req.Ids))
+	for _, id := range req.Ids {
+		if id == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "empty id")
+		}
+		ids = append(ids, model.RecordID(id))
+	}
+	res, err := h.ctrl.BatchGetAggregatedRating(ctx, ids, model.RecordType(req.Type), req.Strategy)
+	if err != nil && (errors.Is(err, rating.ErrUnknownStrategy) || errors.Is(err, rating.ErrInvalidArgument)) {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	resp := &gen.BatchGetAggregatedRatingResponse{}
+	for _, id := range req.Ids {
+		r := &gen.AggregatedRatingResult{Id: id}
+		if v, ok := res[model.RecordID(id)]; ok {
+			r.Rating = v
+		} else {
+			r.Error = &gen.ItemError{Code: int32(codes.NotFound), Message: rating.ErrNotFound.Error()}
+		}
+		resp.Results = append(resp.Results, r)
+	}
+	return resp, nil
+}
+
+// GetRatingStats returns the rating distribution of a record.
+func (h *Handler) GetRatingStats(ctx context.Context, req *gen.GetRatingStatsRequest) (*gen.GetRatingStatsResponse, error) {
+	if req == nil || req.Id == "" || req.Type == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "nil req or empty id")
+	}
+	stats, err := h.ctrl.GetRatingStats(ctx, model.RecordID(req.Id), model.RecordType(req.Type))
+	if err != nil && errors.Is(err, rating.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	return &gen.GetRatingStatsResponse{Stats: model.RatingStatsToProto(stats)}, nil
+}
+
 // PutRating writes a rating for a given record.
 func (h *Handler) PutRating(ctx context.Context, req *gen.PutRatingRequest) (*gen.PutRatingResponse, error) {
 	if req == nil || req.RecordId == "" || req.UserId == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "nil req or empty user id or record id")
 	}
-	if err := h.ctrl.PutRating(ctx, model.RecordID(req.RecordId), model.RecordType(req.RecordType), &model.Rating{UserID: model.UserID(req.UserId), Value: model.RatingValue(req.RatingValue)}); err != nil {
-		return nil, err
+	err := h.ctrl.PutRating(ctx, model.RecordID(req.RecordId), model.RecordType(req.RecordType), &model.Rating{UserID: model.UserID(req.UserId), Value: model.RatingValue(req.RatingValue)})
+	if err != nil && errors.Is(err, rating.ErrInvalidArgument) {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
 	}
 	return &gen.PutRatingResponse{}, nil
 }
+
+// DeleteRating removes the rating of a user for a given record.
+func (h *Handler) DeleteRating(ctx context.Context, req *gen.DeleteRatingRequest) (*gen.DeleteRatingResponse, error) {
+	if req == nil || req.RecordId == "" || req.UserId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "nil req or empty user id or record id")
+	}
+	err := h.ctrl.DeleteRating(ctx, model.RecordID(req.RecordId), model.RecordType(req.RecordType), model.UserID(req.UserId))
+	if err != nil && errors.Is(err, rating.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	return &gen.DeleteRatingResponse{}, nil
+}
+
+// ListUserRatings returns a page of the ratings given by a user.
+func (h *Handler) ListUserRatings(ctx context.Context, req *gen.ListUserRatingsRequest) (*gen.ListUserRatingsResponse, error) {
+	if req == nil || req.UserId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "nil req or empty user id")
+	}
+	ratings, next, err := h.ctrl.ListUserRatings(ctx, model.UserID(req.UserId), model.RecordType(req.RecordType), int(req.PageSize), req.PageToken, req.OldestFirst)
+	if err != nil && errors.Is(err, rating.ErrInvalidArgument) {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	resp := &gen.ListUserRatingsResponse{NextPageToken: next}
+	for _, r := range ratings {
+		resp.Ratings = append(resp.Ratings, &gen.UserRating{
+			RecordId:    string(r.RecordID),
+			RecordType:  string(r.RecordType),
+			RatingValue: int32(r.Value),
+			Timestamp:   timestamppb.New(r.Timestamp),
+		})
+	}
+	return resp, nil
+}
+
+// GetTopRated returns the best rated records of a type.
+func (h *Handler) GetTopRated(ctx context.Context, req *gen.GetTopRatedRequest) (*gen.GetTopRatedResponse, error) {
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "nil req")
+	}
+	records, err := h.ctrl.GetTopRated(ctx, model.TopRatedQuery{
+		RecordType: model.RecordType(req.RecordType),
+		Limit:      int(req.Limit),
+		MinVotes:   req.MinVotes,
+		Window:     req.Window.AsDuration(),
+	})
+	if err != nil && errors.Is(err, rating.ErrInvalidArgument) {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	resp := &gen.GetTopRatedResponse{}
+	for _, r := range records {
+		resp.Records = append(resp.Records, &gen.RankedRecord{RecordId: string(r.RecordID), Rating: r.Rating, Count: r.Count})
+	}
+	return resp, nil
+}
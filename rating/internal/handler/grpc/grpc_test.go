@@ -0,0 +1,57 @@
+package grpc
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"movieexample.com/gen"
+	"movieexample.com/rating/internal/controller/rating"
+	"movieexample.com/rating/internal/repository/memory"
+	"movieexample.com/rating/pkg/model"
+)
+
+func TestBatchGetAggregatedRating(t *testing.T) {
+	ctx := context.Background()
+	h := New(rating.New(memory.New()))
+	for _, req := range []*gen.PutRatingRequest{
+		{UserId: "a", RecordId: "1", RecordType: string(model.RecordTypeMovie), RatingValue: 5},
+		{UserId: "b", RecordId: "1", RecordType: string(model.RecordTypeMovie), RatingValue: 3},
+		{UserId: "a", RecordId: "2", RecordType: string(model.RecordTypeMovie), RatingValue: 2},
+	} {
+		_, err := h.PutRating(ctx, req)
+		assert.NoError(t, err)
+	}
+	tooMany := make([]string, rating.MaxBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = strconv.Itoa(i)
+	}
+
+	resp, err := h.BatchGetAggregatedRating(ctx, &gen.BatchGetAggregatedRatingRequest{Ids: []string{"2", "missing", "1", "2"}, Type: string(model.RecordTypeMovie)})
+	assert.NoError(t, err)
+	var ids []string
+	var values []float64
+	var codesOf []codes.Code
+	for _, r := range resp.Results {
+		ids = append(ids, r.Id)
+		values = append(values, r.Rating)
+		codesOf = append(codesOf, codes.Code(r.GetError().GetCode()))
+	}
+	assert.Equal(t, []string{"2", "missing", "1", "2"}, ids, "one result per requested id in request order")
+	assert.Equal(t, []float64{2, 0, 4, 2}, values)
+	assert.Equal(t, []codes.Code{codes.OK, codes.NotFound, codes.OK, codes.OK}, codesOf)
+
+	for name, req := range map[string]*gen.BatchGetAggregatedRatingRequest{
+		"nil":              nil,
+		"empty type":       {Ids: []string{"1"}},
+		"empty id":         {Ids: []string{"1", ""}, Type: string(model.RecordTypeMovie)},
+		"too many":         {Ids: tooMany, Type: string(model.RecordTypeMovie)},
+		"unknown strategy": {Ids: []string{"1"}, Type: string(model.RecordTypeMovie), Strategy: "median"},
+	} {
+		_, err := h.BatchGetAggregatedRating(ctx, req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), name)
+	}
+}
@@ -34,10 +34,13 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 
 	switch r.Method {
 	case http.MethodGet:
-		v, err := h.ctrl.GetAggregatedRating(r.Context(), recordID, recordType)
+		v, err := h.ctrl.GetAggregatedRating(r.Context(), recordID, recordType, r.FormValue("strategy"))
 		if err != nil && errors.Is(err, rating.ErrNotFound) {
 			w.WriteHeader(http.StatusNotFound)
 			return
+		} else if err != nil && errors.Is(err, rating.ErrUnknownStrategy) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
 		} else if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
@@ -50,19 +53,65 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 
 	case http.MethodPut:
 		userID := model.UserID(r.FormValue("userId"))
-		v, err := strconv.ParseFloat(r.FormValue("value"), 64)
+		if userID == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		v, err := strconv.Atoi(r.FormValue("value"))
 		if err != nil {
 			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
-		if err := h.ctrl.PutRating(r.Context(), recordID, recordType, &model.Rating{UserID: userID, Value: model.RatingValue(v)}); err != nil {
+		err = h.ctrl.PutRating(r.Context(), recordID, recordType, &model.Rating{UserID: userID, Value: model.RatingValue(v)})
+		if err != nil && errors.Is(err, rating.ErrInvalidArgument) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		} else if err != nil {
 			log.Printf("Repository put error: %v\n", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
+	case http.MethodDelete:
+		userID := model.UserID(r.FormValue("userId"))
+		if userID == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		err := h.ctrl.DeleteRating(r.Context(), recordID, recordType, userID)
+		if err != nil && errors.Is(err, rating.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if err != nil {
+			log.Printf("Repository delete error: %v\n", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
 	default:
 		w.WriteHeader(http.StatusBadRequest)
 	}
 
 }
+
+// GetRatingStats handles requests of the rating distribution of a record.
+func (h *Handler) GetRatingStats(w http.ResponseWriter, r *http.Request) {
+	recordID := model.RecordID(r.FormValue("id"))
+	recordType := model.RecordType(r.FormValue("type"))
+	if recordID == "" || recordType == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	stats, err := h.ctrl.GetRatingStats(r.Context(), recordID, recordType)
+	if err != nil && errors.Is(err, rating.ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	} else if err != nil {
+		log.Printf("Repository get stats error: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
+		log.Printf("Response encode error: %v\n", err)
+	}
+}
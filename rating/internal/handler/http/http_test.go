@@ -0,0 +1,30 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"movieexample.com/rating/internal/controller/rating"
+	"movieexample.com/rating/internal/repository/memory"
+)
+
+func TestHandlePut(t *testing.T) {
+	h := New(rating.New(memory.New()))
+	tests := map[string]struct {
+		query string
+		want  int
+	}{
+		"valid":         {query: "id=1&type=movie&userId=a&value=4", want: http.StatusOK},
+		"empty user id": {query: "id=1&type=movie&value=4", want: http.StatusBadRequest},
+		"bad value":     {query: "id=1&type=movie&userId=a&value=x", want: http.StatusBadRequest},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.Handle(w, httptest.NewRequest(http.MethodPut, "/rating?"+tt.query, nil))
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package deadletter
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Headers added to a dead-lettered message next to its original headers.
+const (
+	HeaderReason    = "dlq-reason"
+	HeaderTopic     = "dlq-topic"
+	HeaderPartition = "dlq-partition"
+	HeaderOffset    = "dlq-offset"
+)
+
+// Header defines a message header.
+type Header struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// Letter defines a message that could not be ingested.
+type Letter struct {
+	Topic     string    `json:"topic"`
+	Partition int32     `json:"partition"`
+	Offset    int64     `json:"offset"`
+	Key       []byte    `json:"key,omitempty"`
+	Value     []byte    `json:"value"`
+	Headers   []Header  `json:"headers,omitempty"`
+	Reason    string    `json:"reason"`
+	Time      time.Time `json:"time"`
+}
+
+// OriginalHeaders returns the headers of the message before it was dead-lettered.
+func (l Letter) OriginalHeaders() []Header {
+	var res []Header
+	for _, h := range l.Headers {
+		if !strings.HasPrefix(h.Key, "dlq-") {
+			res = append(res, h)
+		}
+	}
+	return res
+}
+
+// Sink defines a destination of dead letters.
+type Sink interface {
+	Send(l Letter) error
+}
+
+// FileSink appends dead letters to a local file, one JSON object per line.
+type FileSink struct {
+	sync.Mutex
+	f *os.File
+}
+
+// NewFileSink creates a file sink appending to the file at the given path.
+func NewFileSink(path string) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{f: f}, nil
+}
+
+// Send appends a dead letter to the file.
+func (s *FileSink) Send(l Letter) error {
+	b, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	s.Lock()
+	defer s.Unlock()
+	_, err = s.f.Write(append(b, '\n'))
+	return err
+}
+
+// Close closes the file.
+func (s *FileSink) Close() error {
+	return s.f.Close()
+}
+
+// ReadFile returns the dead letters written by a file sink.
+func ReadFile(r io.Reader) ([]Letter, error) {
+	var res []Letter
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var l Letter
+		if err := json.Unmarshal(scanner.Bytes(), &l); err != nil {
+			return nil, err
+		}
+		res = append(res, l)
+	}
+	return res, scanner.Err()
+}
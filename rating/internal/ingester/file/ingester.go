@@ -0,0 +1,374 @@
+package file
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"movieexample.com/rating/internal/ingester"
+	"movieexample.com/rating/internal/ingester/deadletter"
+	"movieexample.com/rating/pkg/model"
+)
+
+// Stdin defines the path that makes the ingester read from the standard input.
+const Stdin = "-"
+
+// defaultCheckpointInterval defines how often the position after the last committed event is written.
+const defaultCheckpointInterval = time.Second
+
+// Ingester defines an ingester of NDJSON rating events from a file or the standard input.
+type Ingester struct {
+	path               string
+	checkpoint         string
+	checkpointInterval time.Duration
+	follow             time.Duration
+	deadLetter         deadletter.Sink
+
+	mu sync.Mutex
+	// committed is the position after the last committed event and written the one in the checkpoint file.
+	// generation counts the files read so far, so that commits of a replaced file are ignored.
+	committed  int64
+	written    int64
+	generation int
+}
+
+// chunk defines bytes read from the input. A reopened chunk starts a new file from offset zero.
+type chunk struct {
+	data     []byte
+	reopened bool
+}
+
+// Option configures a file ingester.
+type Option func(*Ingester)
+
+// WithCheckpoint sets the file that keeps the position after the last committed event.
+// The ingester resumes from that position when it is started again. It has no effect on stdin.
+func WithCheckpoint(path string) Option {
+	return func(i *Ingester) {
+		i.checkpoint = path
+	}
+}
+
+// WithCheckpointInterval sets how often the checkpoint is written while events are committed.
+// The events committed since the last write are delivered again after a crash.
+func WithCheckpointInterval(interval time.Duration) Option {
+	return func(i *Ingester) {
+		i.checkpointInterval = interval
+	}
+}
+
+// WithFollow makes the ingester tail the file, checking for new events at the given interval,
+// instead of stopping at the end of the file.
+func WithFollow(interval time.Duration) Option {
+	return func(i *Ingester) {
+		i.follow = interval
+	}
+}
+
+// WithDeadLetterSink sets the sink of lines that cannot be decoded or are rejected.
+// Without a sink such lines are logged and skipped.
+func WithDeadLetterSink(sink deadletter.Sink) Option {
+	return func(i *Ingester) {
+		i.deadLetter = sink
+	}
+}
+
+// NewIngester creates a new ingester of the NDJSON file at the given path or of stdin if the path is Stdin.
+func NewIngester(path string, opts ...Option) *Ingester {
+	i := &Ingester{path: path, checkpointInterval: defaultCheckpointInterval}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// Ingest starts reading rating events from the last checkpoint. The returned channel is closed
+// once the context is cancelled or, unless the ingester follows the file, at the end of the file.
+// A checkpoint past the end of the file, which was truncated or replaced, restarts from the start.
+// A followed file that is truncated or replaced while it is read is reopened from the start too.
+// The checkpoint is written periodically until the context is cancelled and by Close.
+func (i *Ingester) Ingest(ctx context.Context) (chan ingester.Delivery, error) {
+	r := io.ReadCloser(os.Stdin)
+	var offset int64
+	if i.path != Stdin {
+		f, err := os.Open(i.path)
+		if err != nil {
+			return nil, err
+		}
+		if offset, err = i.startOffset(f); err != nil {
+			f.Close()
+			return nil, err
+		}
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			f.Close()
+			return nil, err
+		}
+		r = f
+		i.mu.Lock()
+		i.committed, i.written, i.generation = offset, offset, 0
+		i.mu.Unlock()
+		if i.checkpoint != "" {
+			go i.writeCheckpoints(ctx)
+		}
+	}
+	ch := make(chan ingester.Delivery, 1)
+	go func() {
+		defer close(ch)
+		chunks := make(chan chunk)
+		go i.read(ctx, r, offset, chunks)
+		var pending []byte
+		var generation int
+		for {
+			select {
+			case c, ok := <-chunks:
+				if !ok {
+					// The last line of a file that is not followed may lack a newline.
+					if len(bytes.TrimSpace(pending)) > 0 {
+						select {
+						case ch <- i.delivery(pending, generation, offset, offset+int64(len(pending))):
+						case <-ctx.Done():
+						}
+					}
+					return
+				}
+				if c.reopened {
+					if len(bytes.TrimSpace(pending)) > 0 {
+						fmt.Printf("Discarding the incomplete last line of the replaced %s\n", i.path)
+					}
+					pending, offset = nil, 0
+					generation++
+					i.restart(generation)
+				}
+				pending = append(pending, c.data...)
+			case <-ctx.Done():
+				return
+			}
+			for {
+				n := bytes.IndexByte(pending, '\n')
+				if n < 0 {
+					break
+				}
+				line := pending[:n]
+				pending = pending[n+1:]
+				start := offset
+				offset += int64(n + 1)
+				if len(bytes.TrimSpace(line)) == 0 {
+					continue
+				}
+				select {
+				case ch <- i.delivery(line, generation, start, offset):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// read sends the chunks read from r, which starts at the given offset, until the end of the
+// input or, if the ingester follows the file, until the context is cancelled. A followed file
+// that is truncated or replaced is reopened. It runs apart from the deliveries so that a
+// blocking read of stdin does not hold up the shutdown.
+func (i *Ingester) read(ctx context.Context, r io.ReadCloser, offset int64, chunks chan<- chunk) {
+	defer close(chunks)
+	if i.path != Stdin {
+		defer func() { r.Close() }()
+	}
+	buf := make([]byte, 64*1024)
+	var reopened bool
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			select {
+			case chunks <- chunk{data: append([]byte(nil), buf[:n]...), reopened: reopened}:
+			case <-ctx.Done():
+				return
+			}
+			offset += int64(n)
+			reopened = false
+		}
+		if errors.Is(err, io.EOF) && i.follow > 0 && i.path != Stdin && i.replaced(r.(*os.File), offset) {
+			if f, err := os.Open(i.path); err != nil {
+				fmt.Println("Reopen error: " + err.Error())
+			} else {
+				fmt.Printf("%s was truncated or replaced, reading it from the start\n", i.path)
+				r.Close()
+				r, offset, reopened = f, 0, true
+				continue
+			}
+		}
+		if errors.Is(err, io.EOF) && i.follow > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(i.follow):
+			}
+		} else if errors.Is(err, io.EOF) {
+			return
+		} else if err != nil {
+			if ctx.Err() == nil {
+				fmt.Println("Read error: " + err.Error())
+			}
+			return
+		}
+	}
+}
+
+// replaced reports whether the file at the ingester's path is no longer f or is shorter
+// than the offset read up to. A missing file is not replaced until it is created again.
+func (i *Ingester) replaced(f *os.File, offset int64) bool {
+	info, err := os.Stat(i.path)
+	if err != nil {
+		return false
+	}
+	current, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return !os.SameFile(info, current) || info.Size() < offset
+}
+
+// delivery returns the delivery of the line between the start and end offsets of the given file generation.
+func (i *Ingester) delivery(line []byte, generation int, start, end int64) ingester.Delivery {
+	d := ingester.Delivery{
+		Commit: func() error {
+			i.commit(generation, end)
+			return nil
+		},
+		Reject: func(reason error) error {
+			if i.deadLetter == nil {
+				fmt.Println("Skipping line: " + reason.Error())
+			} else if err := i.deadLetter.Send(deadletter.Letter{
+				Topic:  i.path,
+				Offset: start,
+				Value:  line,
+				Reason: reason.Error(),
+				Time:   time.Now().UTC(),
+			}); err != nil {
+				return err
+			}
+			i.commit(generation, end)
+			return nil
+		},
+	}
+	if e, err := model.DecodeRatingEvent(line, model.ContentTypeJSON); err != nil {
+		d.Err = fmt.Errorf("decode: %w", err)
+	} else {
+		d.Event = *e
+	}
+	return d
+}
+
+// Close writes the position after the last committed event to the checkpoint.
+func (i *Ingester) Close() error {
+	return i.flushCheckpoint()
+}
+
+// startOffset returns the position to start reading f from, which is the checkpoint unless it
+// is past the end of f.
+func (i *Ingester) startOffset(f *os.File) (int64, error) {
+	offset, err := i.readCheckpoint()
+	if err != nil {
+		return 0, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	if offset > info.Size() {
+		fmt.Printf("Checkpoint %d is past the end of %s, which has %d bytes, reading it from the start\n", offset, i.path, info.Size())
+		return 0, nil
+	}
+	return offset, nil
+}
+
+func (i *Ingester) commit(generation int, offset int64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if generation != i.generation {
+		return
+	}
+	i.committed = max(i.committed, offset)
+}
+
+// restart moves the committed position to the start of a reopened file. The checkpoint of
+// the previous file is overwritten on the next write even if nothing is committed.
+func (i *Ingester) restart(generation int) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.generation = generation
+	i.committed, i.written = 0, -1
+}
+
+// writeCheckpoints writes the checkpoint at the checkpoint interval until the context is cancelled.
+func (i *Ingester) writeCheckpoints(ctx context.Context) {
+	ticker := time.NewTicker(i.checkpointInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := i.flushCheckpoint(); err != nil {
+				fmt.Println("Checkpoint error: " + err.Error())
+			}
+		}
+	}
+}
+
+// flushCheckpoint writes the position after the last committed event unless it is already written.
+func (i *Ingester) flushCheckpoint() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.committed == i.written {
+		return nil
+	}
+	if err := i.writeCheckpoint(i.committed); err != nil {
+		return err
+	}
+	i.written = i.committed
+	return nil
+}
+
+func (i *Ingester) readCheckpoint() (int64, error) {
+	if i.checkpoint == "" {
+		return 0, nil
+	}
+	b, err := os.ReadFile(i.checkpoint)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+}
+
+// writeCheckpoint replaces the checkpoint file so that a crash never leaves a partial checkpoint.
+func (i *Ingester) writeCheckpoint(offset int64) error {
+	if i.checkpoint == "" || i.path == Stdin {
+		return nil
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(i.checkpoint), filepath.Base(i.checkpoint)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.WriteString(strconv.FormatInt(offset, 10)); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), i.checkpoint)
+}
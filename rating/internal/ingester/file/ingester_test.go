@@ -0,0 +1,168 @@
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"movieexample.com/rating/internal/ingester"
+	"movieexample.com/rating/pkg/model"
+)
+
+func TestIngestResumesFromCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ratings.ndjson")
+	checkpoint := filepath.Join(dir, "ratings.checkpoint")
+	require.NoError(t, os.WriteFile(path, []byte(
+		`{"id":"e1","user_id":"a","record_id":"1","record_type":"movie","value":5,"event_type":"put"}`+"\n"+
+			"not json\n"+
+			`{"id":"e2","user_id":"b","record_id":"1","record_type":"movie","value":3,"event_type":"put"}`+"\n"), 0o644))
+
+	in := NewIngester(path, WithCheckpoint(checkpoint))
+	ch, err := in.Ingest(context.Background())
+	require.NoError(t, err)
+	d := <-ch
+	assert.Equal(t, "e1", d.Event.ID)
+	assert.NoError(t, d.Commit())
+	d = <-ch
+	assert.Error(t, d.Err)
+	assert.NoError(t, d.Reject(d.Err))
+	d = <-ch
+	assert.Equal(t, "e2", d.Event.ID)
+	_, ok := <-ch
+	assert.False(t, ok, "the channel is closed at the end of the file")
+	assert.NoError(t, in.Close())
+
+	ch, err = NewIngester(path, WithCheckpoint(checkpoint)).Ingest(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"e2"}, eventIDs(ch), "the uncommitted event is delivered again")
+}
+
+func TestIngestRestartsTruncatedFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ratings.ndjson")
+	checkpoint := filepath.Join(dir, "ratings.checkpoint")
+	require.NoError(t, os.WriteFile(path, []byte(`{"id":"e1","user_id":"a","record_id":"1","record_type":"movie","value":5,"event_type":"put"}`+"\n"), 0o644))
+	require.NoError(t, os.WriteFile(checkpoint, []byte("4096"), 0o644))
+
+	ch, err := NewIngester(path, WithCheckpoint(checkpoint)).Ingest(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"e1"}, eventIDs(ch), "a checkpoint past the end of the file restarts from the start")
+}
+
+func TestIngestWritesCheckpointPeriodically(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ratings.ndjson")
+	checkpoint := filepath.Join(dir, "ratings.checkpoint")
+	line := `{"id":"e1","user_id":"a","record_id":"1","record_type":"movie","value":5,"event_type":"put"}` + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(line+line), 0o644))
+	readCheckpoint := func() string {
+		b, _ := os.ReadFile(checkpoint)
+		return string(b)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	in := NewIngester(path, WithCheckpoint(checkpoint), WithCheckpointInterval(10*time.Millisecond))
+	ch, err := in.Ingest(ctx)
+	require.NoError(t, err)
+	assert.NoError(t, (<-ch).Commit())
+	assert.Eventually(t, func() bool { return readCheckpoint() == strconv.Itoa(len(line)) }, time.Second, 5*time.Millisecond)
+	cancel()
+
+	in = NewIngester(path, WithCheckpoint(checkpoint), WithCheckpointInterval(time.Hour))
+	ch, err = in.Ingest(context.Background())
+	require.NoError(t, err)
+	assert.NoError(t, (<-ch).Commit())
+	assert.Equal(t, strconv.Itoa(len(line)), readCheckpoint(), "commits are only written at the interval")
+	assert.NoError(t, in.Close())
+	assert.Equal(t, strconv.Itoa(2*len(line)), readCheckpoint(), "close writes the last commit")
+}
+
+func TestIngestFollow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratings.ndjson")
+	require.NoError(t, os.WriteFile(path, nil, 0o644))
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := NewIngester(path, WithFollow(10*time.Millisecond)).Ingest(ctx)
+	require.NoError(t, err)
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	defer f.Close()
+	_, err = f.WriteString(`{"id":"e1","user_id":"a","record_id":"1","record_type":"movie",`)
+	require.NoError(t, err)
+	_, err = f.WriteString(`"value":5,"event_type":"put"}` + "\n")
+	require.NoError(t, err)
+
+	d := <-ch
+	assert.Equal(t, model.RatingEvent{ID: "e1", UserID: "a", RecordID: "1", RecordType: model.RecordTypeMovie, Value: 5, EventType: model.RatingEventTypePut}, d.Event)
+	cancel()
+	assert.Empty(t, eventIDs(ch))
+}
+
+func TestIngestFollowReopensReplacedFile(t *testing.T) {
+	event := func(id string) string {
+		return `{"id":"` + id + `","user_id":"a","record_id":"1","record_type":"movie","value":5,"event_type":"put"}` + "\n"
+	}
+	tests := map[string]struct {
+		replace func(path, content string) error
+	}{
+		"truncated": {
+			replace: func(path, content string) error {
+				return os.WriteFile(path, []byte(content), 0o644)
+			},
+		},
+		"rotated": {
+			replace: func(path, content string) error {
+				if err := os.Rename(path, path+".1"); err != nil {
+					return err
+				}
+				return os.WriteFile(path, []byte(content), 0o644)
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "ratings.ndjson")
+			checkpoint := filepath.Join(dir, "ratings.checkpoint")
+			require.NoError(t, os.WriteFile(path, []byte(event("e1")+event("e2")), 0o644))
+			ctx, cancel := context.WithCancel(context.Background())
+			in := NewIngester(path, WithCheckpoint(checkpoint), WithFollow(10*time.Millisecond))
+			ch, err := in.Ingest(ctx)
+			require.NoError(t, err)
+
+			first := <-ch
+			assert.Equal(t, "e1", first.Event.ID)
+			second := <-ch
+			assert.Equal(t, "e2", second.Event.ID)
+			assert.NoError(t, first.Commit())
+
+			require.NoError(t, tt.replace(path, event("e3")))
+			d := <-ch
+			assert.Equal(t, "e3", d.Event.ID, "the new content is read from the start")
+			assert.NoError(t, second.Commit(), "a late commit of the replaced file is ignored")
+			assert.NoError(t, d.Commit())
+			cancel()
+			assert.Empty(t, eventIDs(ch))
+
+			require.NoError(t, in.Close())
+			b, err := os.ReadFile(checkpoint)
+			require.NoError(t, err)
+			assert.Equal(t, strconv.Itoa(len(event("e3"))), string(b))
+		})
+	}
+}
+
+func eventIDs(ch chan ingester.Delivery) []string {
+	var res []string
+	for d := range ch {
+		res = append(res, d.Event.ID)
+	}
+	return res
+}
@@ -0,0 +1,17 @@
+package ingester
+
+import "movieexample.com/rating/pkg/model"
+
+// Delivery defines a rating event delivered by an ingester.
+type Delivery struct {
+	Event model.RatingEvent
+	// Err is set if the message could not be decoded into an event. Such a delivery
+	// must be rejected, which keeps acknowledgements in the order of the messages.
+	Err error
+	// Commit acknowledges that the event has been persisted. Events that are not
+	// committed are delivered again after a restart of the ingester.
+	Commit func() error
+	// Reject acknowledges an event that cannot be persisted. The original message
+	// is handed to the dead-letter sink of the ingester along with the reason.
+	Reject func(reason error) error
+}
@@ -0,0 +1,121 @@
+package kafka
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"movieexample.com/rating/internal/ingester/deadletter"
+)
+
+// DeadLetterProducer publishes dead letters to a Kafka topic.
+type DeadLetterProducer struct {
+	producer *kafka.Producer
+	topic    string
+}
+
+// NewDeadLetterProducer creates a producer of dead letters to the given topic.
+func NewDeadLetterProducer(addr string, topic string) (*DeadLetterProducer, error) {
+	producer, err := kafka.NewProducer(&kafka.ConfigMap{
+		"bootstrap.servers": addr,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &DeadLetterProducer{producer: producer, topic: topic}, nil
+}
+
+// Send publishes a dead letter and waits for its delivery. The letter origin and
+// reason are carried in dlq- headers next to the original headers.
+func (p *DeadLetterProducer) Send(l deadletter.Letter) error {
+	headers := toKafkaHeaders(l.OriginalHeaders())
+	headers = append(headers,
+		kafka.Header{Key: deadletter.HeaderReason, Value: []byte(l.Reason)},
+		kafka.Header{Key: deadletter.HeaderTopic, Value: []byte(l.Topic)},
+		kafka.Header{Key: deadletter.HeaderPartition, Value: []byte(strconv.Itoa(int(l.Partition)))},
+		kafka.Header{Key: deadletter.HeaderOffset, Value: []byte(strconv.FormatInt(l.Offset, 10))},
+	)
+	return produce(p.producer, &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &p.topic, Partition: kafka.PartitionAny},
+		Key:            l.Key,
+		Value:          l.Value,
+		Headers:        headers,
+	})
+}
+
+// Close closes the producer.
+func (p *DeadLetterProducer) Close() {
+	p.producer.Close()
+}
+
+// LetterFromMessage converts a consumed Kafka message into a dead letter.
+// Messages read back from a dead-letter topic keep their original origin and reason.
+func LetterFromMessage(msg *kafka.Message, reason string) deadletter.Letter {
+	l := deadletter.Letter{
+		Partition: msg.TopicPartition.Partition,
+		Offset:    int64(msg.TopicPartition.Offset),
+		Key:       msg.Key,
+		Value:     msg.Value,
+		Headers:   fromKafkaHeaders(msg.Headers),
+		Reason:    reason,
+		Time:      time.Now().UTC(),
+	}
+	if msg.TopicPartition.Topic != nil {
+		l.Topic = *msg.TopicPartition.Topic
+	}
+	for _, h := range msg.Headers {
+		switch h.Key {
+		case deadletter.HeaderReason:
+			l.Reason = string(h.Value)
+		case deadletter.HeaderTopic:
+			l.Topic = string(h.Value)
+		case deadletter.HeaderPartition:
+			if v, err := strconv.Atoi(string(h.Value)); err == nil {
+				l.Partition = int32(v)
+			}
+		case deadletter.HeaderOffset:
+			if v, err := strconv.ParseInt(string(h.Value), 10, 64); err == nil {
+				l.Offset = v
+			}
+		}
+	}
+	return l
+}
+
+// ReplayMessage returns the message that puts a dead letter back into the given topic.
+func ReplayMessage(topic string, l deadletter.Letter) *kafka.Message {
+	return &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+		Key:            l.Key,
+		Value:          l.Value,
+		Headers:        toKafkaHeaders(l.OriginalHeaders()),
+	}
+}
+
+func produce(producer *kafka.Producer, msg *kafka.Message) error {
+	delivery := make(chan kafka.Event, 1)
+	if err := producer.Produce(msg, delivery); err != nil {
+		return err
+	}
+	e := <-delivery
+	if m, ok := e.(*kafka.Message); ok && m.TopicPartition.Error != nil {
+		return m.TopicPartition.Error
+	}
+	return nil
+}
+
+func toKafkaHeaders(headers []deadletter.Header) []kafka.Header {
+	var res []kafka.Header
+	for _, h := range headers {
+		res = append(res, kafka.Header{Key: h.Key, Value: h.Value})
+	}
+	return res
+}
+
+func fromKafkaHeaders(headers []kafka.Header) []deadletter.Header {
+	var res []deadletter.Header
+	for _, h := range headers {
+		res = append(res, deadletter.Header{Key: h.Key, Value: h.Value})
+	}
+	return res
+}
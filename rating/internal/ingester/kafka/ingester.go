@@ -2,66 +2,143 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"movieexample.com/rating/internal/ingester"
+	"movieexample.com/rating/internal/ingester/deadletter"
 	"movieexample.com/rating/pkg/model"
 )
 
+// pollTimeout defines how long a single read waits for a message before the ingester checks for cancellation.
+const pollTimeout = 100 * time.Millisecond
+
+// consumer defines the subset of a Kafka consumer used by the ingester.
+type consumer interface {
+	SubscribeTopics(topics []string, rebalanceCb kafka.RebalanceCb) error
+	ReadMessage(timeout time.Duration) (*kafka.Message, error)
+	StoreMessage(m *kafka.Message) ([]kafka.TopicPartition, error)
+	Close() error
+}
+
 // Ingester defines a Kafka ingester.
 type Ingester struct {
-	consumer *kafka.Consumer
-	topic    string
+	consumer   consumer
+	topic      string
+	deadLetter deadletter.Sink
+}
+
+// Option configures a Kafka ingester.
+type Option func(*Ingester)
+
+// WithDeadLetterSink sets the sink of messages that cannot be decoded or are rejected.
+// Without a sink such messages are logged and skipped.
+func WithDeadLetterSink(sink deadletter.Sink) Option {
+	return func(i *Ingester) {
+		i.deadLetter = sink
+	}
 }
 
-// NewIngester creates a new Kafka ingester.
-func NewIngester(addr string, groupID string, topic string) (*Ingester, error) {
+// NewIngester creates a new Kafka ingester. The offset of a message is only stored once
+// its delivery is committed and stored offsets are committed to the broker periodically,
+// so events are ingested at least once without a broker round trip per event.
+func NewIngester(addr string, groupID string, topic string, opts ...Option) (*Ingester, error) {
 	consumer, err := kafka.NewConsumer(&kafka.ConfigMap{
-		"bootstrap.servers": addr,
-		"group.id":          groupID,
-		"auto.offset.reset": "earliest",
+		"bootstrap.servers":        addr,
+		"group.id":                 groupID,
+		"auto.offset.reset":        "earliest",
+		"enable.auto.commit":       true,
+		"enable.auto.offset.store": false,
 	})
 	if err != nil {
 		return nil, err
 	}
+	return newIngester(consumer, topic, opts...), nil
+}
 
-	return &Ingester{
+func newIngester(consumer consumer, topic string, opts ...Option) *Ingester {
+	i := &Ingester{
 		consumer: consumer,
 		topic:    topic,
-	}, nil
-
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
 }
 
-func (i *Ingester) Ingest(ctx context.Context) (chan model.RatingEvent, error) {
+// Ingest starts consuming rating events. The returned channel is closed once
+// the context is cancelled. The consumer stays open so deliveries that are still
+// in flight can be committed; call Close once they are done.
+func (i *Ingester) Ingest(ctx context.Context) (chan ingester.Delivery, error) {
 	if err := i.consumer.SubscribeTopics([]string{i.topic}, nil); err != nil {
 		return nil, err
 	}
-	ch := make(chan model.RatingEvent, 1)
+	ch := make(chan ingester.Delivery, 1)
 	go func() {
-
+		defer close(ch)
 		for {
 			select {
 			case <-ctx.Done():
-				close(ch)
-				i.consumer.Close()
+				return
 			default:
 			}
 
-			msg, err := i.consumer.ReadMessage(-1)
-			if err != nil {
+			msg, err := i.consumer.ReadMessage(pollTimeout)
+			if kerr, ok := err.(kafka.Error); ok && kerr.Code() == kafka.ErrTimedOut {
+				continue
+			} else if err != nil {
 				fmt.Println("Consumer error: " + err.Error())
 				continue
 			}
-			var event model.RatingEvent
-			if err := json.Unmarshal(msg.Value, &event); err != nil {
-				fmt.Println("Unmarshal error: " + err.Error())
-				continue
+			var d ingester.Delivery
+			if event, err := model.DecodeRatingEvent(msg.Value, contentType(msg)); err != nil {
+				d.Err = fmt.Errorf("decode: %w", err)
+			} else {
+				d.Event = *event
+			}
+			d.Commit = func() error {
+				_, err := i.consumer.StoreMessage(msg)
+				return err
+			}
+			d.Reject = func(reason error) error {
+				return i.reject(msg, reason)
+			}
+			select {
+			case ch <- d:
+			case <-ctx.Done():
+				return
 			}
-			ch <- event
 		}
-
 	}()
 
 	return ch, nil
 }
+
+// Close closes the consumer. Deliveries can no longer be committed afterwards.
+func (i *Ingester) Close() error {
+	return i.consumer.Close()
+}
+
+// reject hands a message to the dead-letter sink and stores its offset.
+func (i *Ingester) reject(msg *kafka.Message, reason error) error {
+	if i.deadLetter == nil {
+		fmt.Println("Skipping message: " + reason.Error())
+	} else if err := i.deadLetter.Send(LetterFromMessage(msg, reason.Error())); err != nil {
+		return err
+	}
+	_, err := i.consumer.StoreMessage(msg)
+	return err
+}
+
+// contentType returns the content type header of a message.
+func contentType(msg *kafka.Message) string {
+	for _, h := range msg.Headers {
+		if strings.EqualFold(h.Key, model.HeaderContentType) {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,151 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"movieexample.com/rating/internal/ingester/deadletter"
+	"movieexample.com/rating/pkg/model"
+)
+
+// errConsumerClosed is returned by a closed fake consumer.
+var errConsumerClosed = errors.New("consumer closed")
+
+// fakeConsumer serves queued messages from memory and records stored offsets.
+type fakeConsumer struct {
+	sync.Mutex
+	messages []*kafka.Message
+	stored   []kafka.Offset
+	closed   bool
+}
+
+func (c *fakeConsumer) SubscribeTopics(topics []string, rebalanceCb kafka.RebalanceCb) error {
+	return nil
+}
+
+func (c *fakeConsumer) ReadMessage(timeout time.Duration) (*kafka.Message, error) {
+	c.Lock()
+	if c.closed {
+		c.Unlock()
+		return nil, errConsumerClosed
+	}
+	if len(c.messages) == 0 {
+		c.Unlock()
+		time.Sleep(timeout)
+		return nil, kafka.NewError(kafka.ErrTimedOut, "timed out", false)
+	}
+	defer c.Unlock()
+	msg := c.messages[0]
+	c.messages = c.messages[1:]
+	return msg, nil
+}
+
+func (c *fakeConsumer) StoreMessage(m *kafka.Message) ([]kafka.TopicPartition, error) {
+	c.Lock()
+	defer c.Unlock()
+	if c.closed {
+		return nil, errConsumerClosed
+	}
+	c.stored = append(c.stored, m.TopicPartition.Offset)
+	return []kafka.TopicPartition{m.TopicPartition}, nil
+}
+
+func (c *fakeConsumer) Close() error {
+	c.Lock()
+	defer c.Unlock()
+	c.closed = true
+	return nil
+}
+
+func message(offset kafka.Offset, value string) *kafka.Message {
+	topic := "ratings"
+	return &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Offset: offset},
+		Value:          []byte(value),
+	}
+}
+
+func withHeader(msg *kafka.Message, key, value string) *kafka.Message {
+	msg.Headers = append(msg.Headers, kafka.Header{Key: key, Value: []byte(value)})
+	return msg
+}
+
+// fakeSink keeps the dead letters in memory.
+type fakeSink struct {
+	letters []deadletter.Letter
+}
+
+func (s *fakeSink) Send(l deadletter.Letter) error {
+	s.letters = append(s.letters, l)
+	return nil
+}
+
+func TestIngestCommitsOnlyCommittedDeliveries(t *testing.T) {
+	c := &fakeConsumer{messages: []*kafka.Message{
+		message(0, `{"id":"e1","user_id":"a","record_id":"1","record_type":"movie","value":5,"event_type":"put"}`),
+		withHeader(message(1, `not json`), "content-type", "application/json"),
+		message(2, `{"id":"e2","user_id":"b","record_id":"1","record_type":"movie","value":3,"event_type":"put"}`),
+	}}
+	ctx, cancel := context.WithCancel(context.Background())
+	sink := &fakeSink{}
+	in := newIngester(c, "ratings", WithDeadLetterSink(sink))
+	ch, err := in.Ingest(ctx)
+	require.NoError(t, err)
+
+	d := <-ch
+	assert.Equal(t, "e1", d.Event.ID)
+	assert.Equal(t, model.RatingValue(5), d.Event.Value)
+	assert.NoError(t, d.Commit())
+
+	d = <-ch
+	assert.Error(t, d.Err)
+	assert.NoError(t, d.Reject(d.Err))
+
+	d = <-ch
+	assert.Equal(t, "e2", d.Event.ID)
+
+	cancel()
+	for range ch {
+	}
+	require.NoError(t, in.Close())
+	c.Lock()
+	defer c.Unlock()
+	assert.Equal(t, []kafka.Offset{0, 1}, c.stored, "uncommitted deliveries are not acknowledged to the broker")
+	if assert.Len(t, sink.letters, 1) {
+		assert.Equal(t, []byte("not json"), sink.letters[0].Value)
+		assert.Equal(t, []deadletter.Header{{Key: "content-type", Value: []byte("application/json")}}, sink.letters[0].Headers)
+		assert.Contains(t, sink.letters[0].Reason, "decode")
+	}
+	assert.True(t, c.closed)
+}
+
+func TestIngestCommitsInFlightDeliveryAfterCancel(t *testing.T) {
+	c := &fakeConsumer{messages: []*kafka.Message{
+		message(0, `{"id":"e1","user_id":"a","record_id":"1","record_type":"movie","value":5,"event_type":"put"}`),
+	}}
+	ctx, cancel := context.WithCancel(context.Background())
+	in := newIngester(c, "ratings")
+	ch, err := in.Ingest(ctx)
+	require.NoError(t, err)
+
+	d := <-ch
+	cancel()
+	for range ch {
+	}
+	c.Lock()
+	assert.False(t, c.closed, "the consumer stays open until the ingester is closed")
+	c.Unlock()
+	assert.NoError(t, d.Commit())
+
+	require.NoError(t, in.Close())
+	assert.ErrorIs(t, d.Commit(), errConsumerClosed)
+	c.Lock()
+	defer c.Unlock()
+	assert.Equal(t, []kafka.Offset{0}, c.stored)
+}
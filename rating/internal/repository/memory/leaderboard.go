@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"slices"
+	"sort"
+
+	model "movieexample.com/rating/pkg/model"
+)
+
+// leaderboard keeps the rated records of every type in rank order so that the best rated
+// records are read from the top instead of sorting all aggregates on every request.
+type leaderboard map[model.RecordType][]model.RankedRecord
+
+// rankedBefore reports whether a ranks above b: by mean rating, then by rating count and then by record id.
+func rankedBefore(a, b model.RankedRecord) bool {
+	if a.Rating != b.Rating {
+		return a.Rating > b.Rating
+	}
+	if a.Count != b.Count {
+		return a.Count > b.Count
+	}
+	return a.RecordID < b.RecordID
+}
+
+// position returns the index of rec in the ranking of its type or where it would be inserted.
+func (l leaderboard) position(recordType model.RecordType, rec model.RankedRecord) int {
+	ranked := l[recordType]
+	return sort.Search(len(ranked), func(i int) bool { return !rankedBefore(ranked[i], rec) })
+}
+
+// update moves a record from the rank of its old running totals to the rank of the new ones.
+// Records without ratings are not ranked.
+func (l leaderboard) update(key recordKey, old, agg model.Aggregate) {
+	if old.Count > 0 {
+		rec := rankedRecord(key.recordID, old)
+		if i := l.position(key.recordType, rec); i < len(l[key.recordType]) && l[key.recordType][i] == rec {
+			l[key.recordType] = slices.Delete(l[key.recordType], i, i+1)
+		}
+	}
+	if agg.Count > 0 {
+		rec := rankedRecord(key.recordID, agg)
+		l[key.recordType] = slices.Insert(l[key.recordType], l.position(key.recordType, rec), rec)
+	}
+	if len(l[key.recordType]) == 0 {
+		delete(l, key.recordType)
+	}
+}
+
+// top returns up to limit records of a type with at least minVotes ratings in rank order.
+func (l leaderboard) top(recordType model.RecordType, minVotes int64, limit int) []model.RankedRecord {
+	var res []model.RankedRecord
+	for _, rec := range l[recordType] {
+		if limit > 0 && len(res) == limit {
+			break
+		}
+		if rec.Count >= minVotes {
+			res = append(res, rec)
+		}
+	}
+	return res
+}
+
+func rankedRecord(recordID model.RecordID, agg model.Aggregate) model.RankedRecord {
+	return model.RankedRecord{RecordID: recordID, Rating: agg.Mean(), Count: agg.Count}
+}
@@ -2,37 +2,372 @@ package memory
 
 import (
 	"context"
+	"sort"
+	"sync"
+	"time"
 
+	"movieexample.com/pkg/outbox"
 	"movieexample.com/rating/internal/repository"
 	model "movieexample.com/rating/pkg/model"
 )
 
+type recordKey struct {
+	recordID   model.RecordID
+	recordType model.RecordType
+}
+
 // Repository defines a rating repository.
 type Repository struct {
-	data map[model.RecordType]map[model.RecordID][]model.Rating
+	sync.RWMutex
+	data       map[model.RecordType]map[model.RecordID]map[model.UserID]model.Rating
+	aggregates map[recordKey]model.Aggregate
+	// daily keeps the running totals of every record per day.
+	daily map[recordKey]map[string]model.Aggregate
+	// ranked keeps the records of every type ordered by their running totals.
+	ranked leaderboard
+	// ratedOn indexes the records of every type rated per day.
+	ratedOn map[model.RecordType]map[string]map[model.RecordID]struct{}
+	// byUser indexes the rated records per user.
+	byUser map[model.UserID]map[recordKey]struct{}
+	// processed keeps the ids of applied rating events.
+	processed map[string]struct{}
+	outbox    *outbox.MemoryStore
 }
 
 func New() *Repository {
 	return &Repository{
-		data: make(map[model.RecordType]map[model.RecordID][]model.Rating),
+		data:       make(map[model.RecordType]map[model.RecordID]map[model.UserID]model.Rating),
+		aggregates: make(map[recordKey]model.Aggregate),
+		daily:      make(map[recordKey]map[string]model.Aggregate),
+		ranked:     leaderboard{},
+		ratedOn:    make(map[model.RecordType]map[string]map[model.RecordID]struct{}),
+		byUser:     make(map[model.UserID]map[recordKey]struct{}),
+		processed:  make(map[string]struct{}),
+		outbox:     outbox.NewMemoryStore(),
 	}
 }
 
+// Outbox returns the outbox of the rating change events.
+func (r *Repository) Outbox() *outbox.MemoryStore {
+	return r.outbox
+}
+
 func (r *Repository) Get(ctx context.Context, recordID model.RecordID, recordType model.RecordType) ([]model.Rating, error) {
+	r.RLock()
+	defer r.RUnlock()
 	if _, ok := r.data[recordType]; !ok {
 		return nil, repository.ErrNotFound
 	}
 	if ratings, ok := r.data[recordType][recordID]; !ok || len(ratings) == 0 {
 		return nil, repository.ErrNotFound
 	}
-	return r.data[recordType][recordID], nil
+	res := make([]model.Rating, 0, len(r.data[recordType][recordID]))
+	for _, rating := range r.data[recordType][recordID] {
+		res = append(res, rating)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].UserID < res[j].UserID })
+	return res, nil
+}
+
+// Put adds or replaces the rating of a user for a given record. A non-empty eventID
+// is recorded as processed together with the rating, the rating of an already
+// processed event is not written again.
+func (r *Repository) Put(ctx context.Context, recordID model.RecordID, recordType model.RecordType, rating *model.Rating, eventID string) error {
+	r.Lock()
+	defer r.Unlock()
+	if r.isProcessed(eventID) {
+		return nil
+	}
+	if err := r.put(recordID, recordType, rating); err != nil {
+		return err
+	}
+	r.markProcessed(eventID)
+	return nil
 }
 
-// Put adds a rating for a given record.
-func (r *Repository) Put(ctx context.Context, recordID model.RecordID, recordType model.RecordType, rating *model.Rating) error {
+// PutBatch adds or replaces a batch of ratings in order. eventIDs holds the ids of
+// the events of the ratings at the same index, the ratings of already processed
+// events are skipped and the others are recorded as processed.
+func (r *Repository) PutBatch(ctx context.Context, ratings []model.Rating, eventIDs []string) error {
+	r.Lock()
+	defer r.Unlock()
+	for i := range ratings {
+		eventID := batchEventID(eventIDs, i)
+		if r.isProcessed(eventID) {
+			continue
+		}
+		if err := r.put(ratings[i].RecordID, ratings[i].RecordType, &ratings[i]); err != nil {
+			return err
+		}
+		r.markProcessed(eventID)
+	}
+	return nil
+}
+
+func batchEventID(eventIDs []string, i int) string {
+	if i < len(eventIDs) {
+		return eventIDs[i]
+	}
+	return ""
+}
+
+func (r *Repository) put(recordID model.RecordID, recordType model.RecordType, rating *model.Rating) error {
+	e, err := repository.RatingChangedEvent(recordID, recordType, rating, false)
+	if err != nil {
+		return err
+	}
 	if _, ok := r.data[recordType]; !ok {
-		r.data[recordType] = map[model.RecordID][]model.Rating{}
+		r.data[recordType] = map[model.RecordID]map[model.UserID]model.Rating{}
+	}
+	if _, ok := r.data[recordType][recordID]; !ok {
+		r.data[recordType][recordID] = map[model.UserID]model.Rating{}
+	}
+	key := recordKey{recordID, recordType}
+	if old, ok := r.data[recordType][recordID][rating.UserID]; ok {
+		r.aggregate(key, &old, -1)
+	}
+	r.aggregate(key, rating, 1)
+	stored := *rating
+	stored.RecordID, stored.RecordType = recordID, recordType
+	r.data[recordType][recordID][rating.UserID] = stored
+	if _, ok := r.byUser[rating.UserID]; !ok {
+		r.byUser[rating.UserID] = map[recordKey]struct{}{}
+	}
+	r.byUser[rating.UserID][key] = struct{}{}
+	r.outbox.Add(e)
+	return nil
+}
+
+// Delete removes the rating of a user for a given record. A non-empty eventID is
+// recorded as processed together with the removal. Deleting a missing rating for
+// an event only records the event, without an event it returns ErrNotFound.
+func (r *Repository) Delete(ctx context.Context, recordID model.RecordID, recordType model.RecordType, userID model.UserID, eventID string) error {
+	r.Lock()
+	defer r.Unlock()
+	if r.isProcessed(eventID) {
+		return nil
+	}
+	old, ok := r.data[recordType][recordID][userID]
+	if !ok && eventID != "" {
+		r.markProcessed(eventID)
+		return nil
+	} else if !ok {
+		return repository.ErrNotFound
+	}
+	e, err := repository.RatingChangedEvent(recordID, recordType, &old, true)
+	if err != nil {
+		return err
+	}
+	delete(r.data[recordType][recordID], userID)
+	key := recordKey{recordID, recordType}
+	delete(r.byUser[userID], key)
+	if len(r.byUser[userID]) == 0 {
+		delete(r.byUser, userID)
+	}
+	r.aggregate(key, &old, -1)
+	r.outbox.Add(e)
+	r.markProcessed(eventID)
+	return nil
+}
+
+// aggregate adds (sign 1) or removes (sign -1) a rating to the running totals
+// of its record and of the day it was given and moves the record in the leaderboard.
+func (r *Repository) aggregate(key recordKey, rating *model.Rating, sign int64) {
+	old := r.aggregates[key]
+	agg := old
+	agg.Sum += sign * int64(rating.Value)
+	agg.Count += sign
+	if agg.Count == 0 {
+		delete(r.aggregates, key)
+	} else {
+		r.aggregates[key] = agg
+	}
+	r.ranked.update(key, old, agg)
+
+	day := rating.Timestamp.UTC().Format(model.DayLayout)
+	if _, ok := r.daily[key]; !ok {
+		r.daily[key] = map[string]model.Aggregate{}
+	}
+	dayAgg := r.daily[key][day]
+	dayAgg.Sum += sign * int64(rating.Value)
+	dayAgg.Count += sign
+	if dayAgg.Count == 0 {
+		delete(r.daily[key], day)
+		if len(r.daily[key]) == 0 {
+			delete(r.daily, key)
+		}
+		r.unmarkRatedOn(key, day)
+	} else {
+		r.daily[key][day] = dayAgg
+		r.markRatedOn(key, day)
+	}
+}
+
+func (r *Repository) markRatedOn(key recordKey, day string) {
+	if _, ok := r.ratedOn[key.recordType]; !ok {
+		r.ratedOn[key.recordType] = map[string]map[model.RecordID]struct{}{}
+	}
+	if _, ok := r.ratedOn[key.recordType][day]; !ok {
+		r.ratedOn[key.recordType][day] = map[model.RecordID]struct{}{}
+	}
+	r.ratedOn[key.recordType][day][key.recordID] = struct{}{}
+}
+
+func (r *Repository) unmarkRatedOn(key recordKey, day string) {
+	delete(r.ratedOn[key.recordType][day], key.recordID)
+	if len(r.ratedOn[key.recordType][day]) == 0 {
+		delete(r.ratedOn[key.recordType], day)
+	}
+	if len(r.ratedOn[key.recordType]) == 0 {
+		delete(r.ratedOn, key.recordType)
+	}
+}
+
+// ListByUser returns the ratings given by a user sorted by time.
+func (r *Repository) ListByUser(ctx context.Context, userID model.UserID, opts model.UserRatingsOptions) ([]model.Rating, error) {
+	r.RLock()
+	defer r.RUnlock()
+	var res []model.Rating
+	for key := range r.byUser[userID] {
+		if opts.RecordType != "" && key.recordType != opts.RecordType {
+			continue
+		}
+		rating := r.data[key.recordType][key.recordID][userID]
+		if opts.After != nil {
+			c := model.CursorOf(&rating)
+			if opts.OldestFirst && !opts.After.Before(c) || !opts.OldestFirst && !c.Before(opts.After) {
+				continue
+			}
+		}
+		res = append(res, rating)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if opts.OldestFirst {
+			return model.CursorOf(&res[i]).Before(model.CursorOf(&res[j]))
+		}
+		return model.CursorOf(&res[j]).Before(model.CursorOf(&res[i]))
+	})
+	if opts.Limit > 0 && len(res) > opts.Limit {
+		res = res[:opts.Limit]
+	}
+	return res, nil
+}
+
+// GetAggregate returns the running totals of the ratings of a given record.
+func (r *Repository) GetAggregate(ctx context.Context, recordID model.RecordID, recordType model.RecordType) (*model.Aggregate, error) {
+	r.RLock()
+	defer r.RUnlock()
+	agg, ok := r.aggregates[recordKey{recordID, recordType}]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &agg, nil
+}
+
+// GetAggregates returns the running totals of the ratings of the given records of a type keyed by record id.
+// Records without ratings are absent from the result.
+func (r *Repository) GetAggregates(_ context.Context, recordIDs []model.RecordID, recordType model.RecordType) (map[model.RecordID]*model.Aggregate, error) {
+	r.RLock()
+	defer r.RUnlock()
+	res := make(map[model.RecordID]*model.Aggregate, len(recordIDs))
+	for _, id := range recordIDs {
+		if agg, ok := r.aggregates[recordKey{id, recordType}]; ok {
+			res[id] = &agg
+		}
+	}
+	return res, nil
+}
+
+// GetTopRated returns the records of a type with the highest average rating from the leaderboard.
+// With a window only ratings given in the last window, rounded up to whole days, are counted
+// and only the records rated in the window are ranked.
+func (r *Repository) GetTopRated(ctx context.Context, q model.TopRatedQuery) ([]model.RankedRecord, error) {
+	r.RLock()
+	defer r.RUnlock()
+	if q.Window == 0 {
+		return r.ranked.top(q.RecordType, q.MinVotes, q.Limit), nil
+	}
+	since := time.Now().UTC().Add(-q.Window).Format(model.DayLayout)
+	windowed := map[model.RecordID]model.Aggregate{}
+	for day, records := range r.ratedOn[q.RecordType] {
+		if day < since {
+			continue
+		}
+		for id := range records {
+			dayAgg := r.daily[recordKey{id, q.RecordType}][day]
+			agg := windowed[id]
+			agg.Sum += dayAgg.Sum
+			agg.Count += dayAgg.Count
+			windowed[id] = agg
+		}
+	}
+	var res []model.RankedRecord
+	for id, agg := range windowed {
+		if agg.Count == 0 || agg.Count < q.MinVotes {
+			continue
+		}
+		res = append(res, rankedRecord(id, agg))
+	}
+	sort.Slice(res, func(i, j int) bool { return rankedBefore(res[i], res[j]) })
+	if q.Limit > 0 && len(res) > q.Limit {
+		res = res[:q.Limit]
+	}
+	return res, nil
+}
+
+// GetStats returns the distribution of the ratings of a given record.
+func (r *Repository) GetStats(ctx context.Context, recordID model.RecordID, recordType model.RecordType) (*model.RatingStats, error) {
+	r.RLock()
+	defer r.RUnlock()
+	ratings := r.data[recordType][recordID]
+	if len(ratings) == 0 {
+		return nil, repository.ErrNotFound
+	}
+	histogram := map[model.RatingValue]int64{}
+	for _, rating := range ratings {
+		histogram[rating.Value]++
+	}
+	return model.NewRatingStats(histogram), nil
+}
+
+// RebuildAggregates recomputes the running totals of all records from the stored ratings.
+func (r *Repository) RebuildAggregates(ctx context.Context) error {
+	r.Lock()
+	defer r.Unlock()
+	r.aggregates = make(map[recordKey]model.Aggregate)
+	r.daily = make(map[recordKey]map[string]model.Aggregate)
+	r.ranked = leaderboard{}
+	r.ratedOn = make(map[model.RecordType]map[string]map[model.RecordID]struct{})
+	for recordType, records := range r.data {
+		for recordID, ratings := range records {
+			for _, rating := range ratings {
+				r.aggregate(recordKey{recordID, recordType}, &rating, 1)
+			}
+		}
 	}
-	r.data[recordType][recordID] = append(r.data[recordType][recordID], *rating)
 	return nil
 }
+
+// ProcessedEvents returns which of the given rating events have been applied.
+func (r *Repository) ProcessedEvents(ctx context.Context, eventIDs []string) (map[string]bool, error) {
+	r.RLock()
+	defer r.RUnlock()
+	res := map[string]bool{}
+	for _, id := range eventIDs {
+		if _, ok := r.processed[id]; ok {
+			res[id] = true
+		}
+	}
+	return res, nil
+}
+
+func (r *Repository) isProcessed(eventID string) bool {
+	_, ok := r.processed[eventID]
+	return eventID != "" && ok
+}
+
+func (r *Repository) markProcessed(eventID string) {
+	if eventID != "" {
+		r.processed[eventID] = struct{}{}
+	}
+}
@@ -0,0 +1,196 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"movieexample.com/pkg/outbox"
+	"movieexample.com/rating/internal/repository"
+	"movieexample.com/rating/pkg/model"
+)
+
+func TestAggregates(t *testing.T) {
+	ctx := context.Background()
+	r := New()
+	id, typ := model.RecordID("1"), model.RecordTypeMovie
+
+	assert.NoError(t, r.Put(ctx, id, typ, &model.Rating{UserID: "a", Value: 5}, ""))
+	assert.NoError(t, r.Put(ctx, id, typ, &model.Rating{UserID: "b", Value: 3}, ""))
+	assert.NoError(t, r.Put(ctx, id, typ, &model.Rating{UserID: "a", Value: 1}, ""))
+	agg, err := r.GetAggregate(ctx, id, typ)
+	assert.NoError(t, err)
+	assert.Equal(t, &model.Aggregate{Sum: 4, Count: 2}, agg, "a repeated rating replaces the earlier one")
+
+	assert.NoError(t, r.Delete(ctx, id, typ, "b", ""))
+	agg, _ = r.GetAggregate(ctx, id, typ)
+	assert.Equal(t, &model.Aggregate{Sum: 1, Count: 1}, agg)
+
+	aggs, err := r.GetAggregates(ctx, []model.RecordID{id, "2"}, typ)
+	assert.NoError(t, err)
+	assert.Equal(t, map[model.RecordID]*model.Aggregate{id: {Sum: 1, Count: 1}}, aggs, "records without ratings are absent")
+
+	r.aggregates[recordKey{id, typ}] = model.Aggregate{Sum: 100, Count: 7}
+	assert.NoError(t, r.RebuildAggregates(ctx))
+	agg, _ = r.GetAggregate(ctx, id, typ)
+	assert.Equal(t, &model.Aggregate{Sum: 1, Count: 1}, agg)
+
+	assert.NoError(t, r.Delete(ctx, id, typ, "a", ""))
+	_, err = r.GetAggregate(ctx, id, typ)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestPutOverwritesAndDelete(t *testing.T) {
+	ctx := context.Background()
+	r := New()
+	id, typ := model.RecordID("1"), model.RecordTypeMovie
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, r.Put(ctx, id, typ, &model.Rating{UserID: "a", Value: 5, Timestamp: at}, ""))
+	assert.NoError(t, r.Put(ctx, id, typ, &model.Rating{UserID: "b", Value: 2, Timestamp: at}, ""))
+	assert.NoError(t, r.Put(ctx, id, typ, &model.Rating{UserID: "a", Value: 1, Timestamp: at.Add(time.Hour)}, ""))
+	res, err := r.Get(ctx, id, typ)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Rating{
+		{RecordID: id, RecordType: typ, UserID: "a", Value: 1, Timestamp: at.Add(time.Hour)},
+		{RecordID: id, RecordType: typ, UserID: "b", Value: 2, Timestamp: at},
+	}, res, "a user has a single rating per record")
+
+	assert.NoError(t, r.Delete(ctx, id, typ, "a", ""))
+	res, _ = r.Get(ctx, id, typ)
+	if assert.Len(t, res, 1) {
+		assert.Equal(t, model.UserID("b"), res[0].UserID)
+	}
+	assert.ErrorIs(t, r.Delete(ctx, id, typ, "a", ""), repository.ErrNotFound)
+
+	assert.NoError(t, r.Delete(ctx, id, typ, "b", ""))
+	_, err = r.Get(ctx, id, typ)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestListByUser(t *testing.T) {
+	ctx := context.Background()
+	r := New()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, id := range []model.RecordID{"1", "2", "3"} {
+		assert.NoError(t, r.Put(ctx, id, model.RecordTypeMovie, &model.Rating{UserID: "a", Value: 5, Timestamp: start.Add(time.Duration(i) * time.Hour)}, ""))
+	}
+	assert.NoError(t, r.Put(ctx, "1", "episode", &model.Rating{UserID: "a", Value: 5, Timestamp: start}, ""))
+	assert.NoError(t, r.Put(ctx, "1", model.RecordTypeMovie, &model.Rating{UserID: "b", Value: 5, Timestamp: start}, ""))
+
+	ids := func(ratings []model.Rating) []model.RecordID {
+		var ids []model.RecordID
+		for _, r := range ratings {
+			ids = append(ids, r.RecordID)
+		}
+		return ids
+	}
+
+	res, err := r.ListByUser(ctx, "a", model.UserRatingsOptions{RecordType: model.RecordTypeMovie, Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.RecordID{"3", "2"}, ids(res))
+
+	res, _ = r.ListByUser(ctx, "a", model.UserRatingsOptions{RecordType: model.RecordTypeMovie, After: model.CursorOf(&res[1])})
+	assert.Equal(t, []model.RecordID{"1"}, ids(res))
+
+	res, _ = r.ListByUser(ctx, "a", model.UserRatingsOptions{OldestFirst: true})
+	assert.Len(t, res, 4)
+	assert.Equal(t, model.RecordType("episode"), res[0].RecordType)
+
+	assert.NoError(t, r.Delete(ctx, "2", model.RecordTypeMovie, "a", ""))
+	res, _ = r.ListByUser(ctx, "a", model.UserRatingsOptions{RecordType: model.RecordTypeMovie})
+	assert.Equal(t, []model.RecordID{"3", "1"}, ids(res))
+}
+
+func TestGetTopRated(t *testing.T) {
+	ctx := context.Background()
+	r := New()
+	now := time.Now().UTC()
+	old := now.Add(-30 * 24 * time.Hour)
+	put := func(id model.RecordID, user model.UserID, v model.RatingValue, ts time.Time) {
+		assert.NoError(t, r.Put(ctx, id, model.RecordTypeMovie, &model.Rating{UserID: user, Value: v, Timestamp: ts}, ""))
+	}
+	put("classic", "a", 5, old)
+	put("classic", "b", 5, old)
+	put("classic", "c", 4, now)
+	put("new", "a", 4, now)
+	put("new", "b", 4, now)
+	put("single", "a", 5, now)
+
+	ids := func(records []model.RankedRecord) []model.RecordID {
+		var ids []model.RecordID
+		for _, r := range records {
+			ids = append(ids, r.RecordID)
+		}
+		return ids
+	}
+
+	res, err := r.GetTopRated(ctx, model.TopRatedQuery{RecordType: model.RecordTypeMovie, MinVotes: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.RecordID{"classic", "new"}, ids(res))
+
+	res, _ = r.GetTopRated(ctx, model.TopRatedQuery{RecordType: model.RecordTypeMovie, Window: 7 * 24 * time.Hour})
+	assert.Equal(t, []model.RecordID{"single", "new", "classic"}, ids(res))
+	assert.Equal(t, int64(1), res[2].Count)
+
+	put("classic", "c", 1, now)
+	res, _ = r.GetTopRated(ctx, model.TopRatedQuery{RecordType: model.RecordTypeMovie, Window: 7 * 24 * time.Hour, Limit: 1, MinVotes: 2})
+	assert.Equal(t, []model.RecordID{"new"}, ids(res))
+
+	res, _ = r.GetTopRated(ctx, model.TopRatedQuery{RecordType: model.RecordTypeMovie})
+	assert.Equal(t, []model.RecordID{"single", "new", "classic"}, ids(res), "the leaderboard follows overwritten ratings")
+	assert.NoError(t, r.Delete(ctx, "single", model.RecordTypeMovie, "a", ""))
+	res, _ = r.GetTopRated(ctx, model.TopRatedQuery{RecordType: model.RecordTypeMovie, Limit: 1})
+	assert.Equal(t, []model.RecordID{"new"}, ids(res), "records without ratings leave the leaderboard")
+	assert.NoError(t, r.RebuildAggregates(ctx))
+	res, _ = r.GetTopRated(ctx, model.TopRatedQuery{RecordType: model.RecordTypeMovie})
+	assert.Equal(t, []model.RankedRecord{{RecordID: "new", Rating: 4, Count: 2}, {RecordID: "classic", Rating: 11.0 / 3, Count: 3}}, res)
+	res, _ = r.GetTopRated(ctx, model.TopRatedQuery{RecordType: model.RecordTypeEpisode})
+	assert.Empty(t, res)
+}
+
+func TestOutbox(t *testing.T) {
+	ctx := context.Background()
+	r := New()
+	assert.NoError(t, r.Put(ctx, "1", model.RecordTypeMovie, &model.Rating{UserID: "a", Value: 5}, ""))
+	assert.NoError(t, r.Delete(ctx, "1", model.RecordTypeMovie, "a", ""))
+	assert.ErrorIs(t, r.Delete(ctx, "1", model.RecordTypeMovie, "a", ""), repository.ErrNotFound)
+
+	events, err := r.Outbox().Pending(ctx, 10)
+	assert.NoError(t, err)
+	require.Len(t, events, 2)
+	var changes []model.RatingChanged
+	for _, e := range events {
+		assert.Equal(t, outbox.TypeRatingChanged, e.Type)
+		assert.Equal(t, "movie/1", e.AggregateID)
+		var c model.RatingChanged
+		require.NoError(t, json.Unmarshal(e.Payload, &c))
+		changes = append(changes, c)
+	}
+	assert.Equal(t, []model.RatingChanged{
+		{RecordID: "1", RecordType: model.RecordTypeMovie, UserID: "a", Value: 5},
+		{RecordID: "1", RecordType: model.RecordTypeMovie, UserID: "a", Deleted: true},
+	}, changes)
+}
+
+func TestEventWritesRecordProcessedEvents(t *testing.T) {
+	ctx := context.Background()
+	r := New()
+	assert.NoError(t, r.Put(ctx, "1", model.RecordTypeMovie, &model.Rating{UserID: "a", Value: 5}, "e1"))
+	assert.NoError(t, r.Put(ctx, "1", model.RecordTypeMovie, &model.Rating{UserID: "a", Value: 1}, "e1"))
+	assert.NoError(t, r.Delete(ctx, "1", model.RecordTypeMovie, "b", "e2"), "deleting a missing rating for an event only records it")
+	assert.NoError(t, r.PutBatch(ctx, []model.Rating{
+		{RecordID: "1", RecordType: model.RecordTypeMovie, UserID: "a", Value: 2},
+		{RecordID: "1", RecordType: model.RecordTypeMovie, UserID: "b", Value: 3},
+	}, []string{"e1", "e3"}))
+
+	agg, err := r.GetAggregate(ctx, "1", model.RecordTypeMovie)
+	assert.NoError(t, err)
+	assert.Equal(t, &model.Aggregate{Sum: 8, Count: 2}, agg, "the redelivered puts of e1 are skipped")
+	processed, err := r.ProcessedEvents(ctx, []string{"e1", "e2", "e3", "e4"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"e1": true, "e2": true, "e3": true}, processed)
+}
@@ -0,0 +1,256 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"movieexample.com/pkg/outbox"
+	outboxmysql "movieexample.com/pkg/outbox/mysql"
+	"movieexample.com/rating/internal/repository"
+	"movieexample.com/rating/pkg/model"
+)
+
+// maxBatchRows limits the rows of a single multi-row statement to stay below the placeholder limit.
+const maxBatchRows = 1000
+
+type ratingKey struct {
+	recordID   model.RecordID
+	recordType model.RecordType
+	userID     model.UserID
+}
+
+type dayKey struct {
+	recordID   model.RecordID
+	recordType model.RecordType
+	day        string
+}
+
+// PutBatch adds or replaces a batch of ratings and updates the record aggregates in a single transaction.
+// A later rating of a user for a record replaces an earlier one of the same batch. eventIDs holds the ids
+// of the events of the ratings at the same index, the ratings of already processed events are skipped and
+// the others are recorded as processed in the same transaction.
+func (r *Repository) PutBatch(ctx context.Context, ratings []model.Rating, eventIDs []string) error {
+	if len(ratings) == 0 {
+		return nil
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	ratings, err = recordEvents(ctx, tx, ratings, eventIDs)
+	if err != nil {
+		return err
+	}
+	ratings = latestRatings(ratings)
+	if len(ratings) == 0 {
+		return tx.Commit()
+	}
+	totals := map[ratingKey]model.Aggregate{}
+	daily := map[dayKey]model.Aggregate{}
+	add := func(rating model.Rating, sign int64) {
+		k := ratingKey{rating.RecordID, rating.RecordType, ""}
+		t := totals[k]
+		t.Sum += sign * int64(rating.Value)
+		t.Count += sign
+		totals[k] = t
+		dk := dayKey{rating.RecordID, rating.RecordType, rating.Timestamp.UTC().Format(model.DayLayout)}
+		d := daily[dk]
+		d.Sum += sign * int64(rating.Value)
+		d.Count += sign
+		daily[dk] = d
+	}
+	for start := 0; start < len(ratings); start += maxBatchRows {
+		chunk := ratings[start:min(start+maxBatchRows, len(ratings))]
+		old, err := lockRatings(ctx, tx, chunk)
+		if err != nil {
+			return err
+		}
+		args := make([]any, 0, len(chunk)*5)
+		for _, rating := range chunk {
+			if o, ok := old[ratingKey{rating.RecordID, rating.RecordType, rating.UserID}]; ok {
+				add(o, -1)
+			}
+			add(rating, 1)
+			args = append(args, rating.RecordID, rating.RecordType, rating.UserID, rating.Value, rating.Timestamp)
+		}
+		if _, err := tx.ExecContext(ctx, "INSERT INTO ratings(record_id, record_type, user_id, value, rated_at) VALUES "+valueRows(len(chunk), 5)+" ON DUPLICATE KEY UPDATE value = VALUES(value), rated_at = VALUES(rated_at)", args...); err != nil {
+			return err
+		}
+	}
+	var totalArgs []any
+	for k, t := range totals {
+		totalArgs = append(totalArgs, k.recordID, k.recordType, t.Sum, t.Count)
+	}
+	if err := execRows(ctx, tx, "INSERT INTO rating_aggregates (record_id, record_type, rating_sum, rating_count) VALUES %s ON DUPLICATE KEY UPDATE rating_sum = rating_sum + VALUES(rating_sum), rating_count = rating_count + VALUES(rating_count)", 4, totalArgs); err != nil {
+		return err
+	}
+	var dailyArgs []any
+	for k, d := range daily {
+		dailyArgs = append(dailyArgs, k.recordID, k.recordType, k.day, d.Sum, d.Count)
+	}
+	if err := execRows(ctx, tx, "INSERT INTO rating_daily_aggregates (record_id, record_type, day, rating_sum, rating_count) VALUES %s ON DUPLICATE KEY UPDATE rating_sum = rating_sum + VALUES(rating_sum), rating_count = rating_count + VALUES(rating_count)", 5, dailyArgs); err != nil {
+		return err
+	}
+	events := make([]outbox.Event, 0, len(ratings))
+	for i := range ratings {
+		e, err := repository.RatingChangedEvent(ratings[i].RecordID, ratings[i].RecordType, &ratings[i], false)
+		if err != nil {
+			return err
+		}
+		events = append(events, e)
+	}
+	for start := 0; start < len(events); start += maxBatchRows {
+		if err := outboxmysql.Insert(ctx, tx, outboxTable, events[start:min(start+maxBatchRows, len(events))]...); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// ProcessedEvents returns which of the given rating events have been applied.
+func (r *Repository) ProcessedEvents(ctx context.Context, eventIDs []string) (map[string]bool, error) {
+	return processedEvents(ctx, r.db, eventIDs, "")
+}
+
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// processedEvents returns which of the given rating events have been applied. The suffix is
+// appended to the query, e.g. to lock the rows for the rest of a transaction.
+func processedEvents(ctx context.Context, db querier, eventIDs []string, suffix string) (map[string]bool, error) {
+	res := map[string]bool{}
+	for start := 0; start < len(eventIDs); start += maxBatchRows {
+		chunk := eventIDs[start:min(start+maxBatchRows, len(eventIDs))]
+		args := make([]any, len(chunk))
+		for i, id := range chunk {
+			args[i] = id
+		}
+		rows, err := db.QueryContext(ctx, "SELECT event_id FROM rating_processed_events WHERE event_id IN ("+strings.TrimSuffix(strings.Repeat("?, ", len(chunk)), ", ")+")"+suffix, args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			res[id] = true
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		rows.Close()
+	}
+	return res, nil
+}
+
+// recordEvent records a rating event as processed within a transaction and reports whether
+// it had not been processed before. A write without an event is always applied.
+func recordEvent(ctx context.Context, tx *sql.Tx, eventID string) (bool, error) {
+	if eventID == "" {
+		return true, nil
+	}
+	res, err := tx.ExecContext(ctx, "INSERT IGNORE INTO rating_processed_events(event_id) VALUES (?)", eventID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// recordEvents records the events of a batch of ratings as processed within a transaction and
+// returns the ratings whose events had not been processed before. eventIDs holds the ids of the
+// events of the ratings at the same index, ratings without an event are always returned.
+func recordEvents(ctx context.Context, tx *sql.Tx, ratings []model.Rating, eventIDs []string) ([]model.Rating, error) {
+	var ids []string
+	for _, id := range eventIDs {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return ratings, nil
+	}
+	processed, err := processedEvents(ctx, tx, ids, " FOR UPDATE")
+	if err != nil {
+		return nil, err
+	}
+	res := make([]model.Rating, 0, len(ratings))
+	var args []any
+	for i, rating := range ratings {
+		if i < len(eventIDs) && eventIDs[i] != "" {
+			if processed[eventIDs[i]] {
+				continue
+			}
+			args = append(args, eventIDs[i])
+		}
+		res = append(res, rating)
+	}
+	if err := execRows(ctx, tx, "INSERT IGNORE INTO rating_processed_events(event_id) VALUES %s", 1, args); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// latestRatings drops the ratings of a batch that are replaced by a later rating of the same user for the same record.
+func latestRatings(ratings []model.Rating) []model.Rating {
+	last := make(map[ratingKey]int, len(ratings))
+	for i, rating := range ratings {
+		last[ratingKey{rating.RecordID, rating.RecordType, rating.UserID}] = i
+	}
+	res := make([]model.Rating, 0, len(last))
+	for i, rating := range ratings {
+		if last[ratingKey{rating.RecordID, rating.RecordType, rating.UserID}] == i {
+			res = append(res, rating)
+		}
+	}
+	return res
+}
+
+// lockRatings locks the existing rating rows of a batch for the rest of the transaction and returns them.
+func lockRatings(ctx context.Context, tx *sql.Tx, ratings []model.Rating) (map[ratingKey]model.Rating, error) {
+	args := make([]any, 0, len(ratings)*3)
+	for _, rating := range ratings {
+		args = append(args, rating.RecordID, rating.RecordType, rating.UserID)
+	}
+	rows, err := tx.QueryContext(ctx, "SELECT record_id, record_type, user_id, value, rated_at FROM ratings WHERE (record_id, record_type, user_id) IN ("+valueRows(len(ratings), 3)+") FOR UPDATE", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := map[ratingKey]model.Rating{}
+	for rows.Next() {
+		var rating model.Rating
+		if err := rows.Scan(&rating.RecordID, &rating.RecordType, &rating.UserID, &rating.Value, &rating.Timestamp); err != nil {
+			return nil, err
+		}
+		res[ratingKey{rating.RecordID, rating.RecordType, rating.UserID}] = rating
+	}
+	return res, rows.Err()
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// execRows executes a multi-row statement whose %s is replaced by the value rows, in chunks of at most maxBatchRows.
+func execRows(ctx context.Context, db execer, query string, cols int, args []any) error {
+	for start := 0; start < len(args); start += maxBatchRows * cols {
+		chunk := args[start:min(start+maxBatchRows*cols, len(args))]
+		if _, err := db.ExecContext(ctx, strings.Replace(query, "%s", valueRows(len(chunk)/cols, cols), 1), chunk...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// valueRows returns the placeholders of a multi-row statement, e.g. "(?, ?), (?, ?)".
+func valueRows(rows, cols int) string {
+	row := "(" + strings.TrimSuffix(strings.Repeat("?, ", cols), ", ") + ")"
+	return strings.TrimSuffix(strings.Repeat(row+", ", rows), ", ")
+}
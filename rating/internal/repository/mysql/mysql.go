@@ -3,27 +3,38 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"strings"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
+	outboxmysql "movieexample.com/pkg/outbox/mysql"
 	"movieexample.com/rating/internal/repository"
 	"movieexample.com/rating/pkg/model"
 )
 
+// outboxTable defines the table of the rating change events.
+const outboxTable = "rating_outbox"
+
 type Repository struct {
 	db *sql.DB
 }
 
 func New() (*Repository, error) {
-	db, err := sql.Open("mysql", "root:password@/movieexample")
+	db, err := sql.Open("mysql", "root:password@/movieexample?parseTime=true")
 	if err != nil {
 		return nil, err
 	}
 	return &Repository{db}, nil
 }
 
+// Outbox returns the outbox of the rating change events.
+func (r *Repository) Outbox() *outboxmysql.Store {
+	return outboxmysql.NewStore(r.db, outboxTable)
+}
+
 // Get retrieves all ratings for a given record.
 func (r *Repository) Get(ctx context.Context, recordID model.RecordID, recordType model.RecordType) ([]model.Rating, error) {
-	rows, err := r.db.QueryContext(ctx, "SELECT user_id, value FROM ratings WHERE record_id = ? AND record_type = ?", recordID, recordType)
+	rows, err := r.db.QueryContext(ctx, "SELECT user_id, value, rated_at FROM ratings WHERE record_id = ? AND record_type = ?", recordID, recordType)
 	if err != nil {
 		return nil, err
 	}
@@ -32,10 +43,11 @@ func (r *Repository) Get(ctx context.Context, recordID model.RecordID, recordTyp
 	for rows.Next() {
 		var user_id string
 		var value int32
-		if err := rows.Scan(&user_id, &value); err != nil {
+		var ratedAt time.Time
+		if err := rows.Scan(&user_id, &value, &ratedAt); err != nil {
 			return nil, err
 		}
-		ratings = append(ratings, model.Rating{UserID: model.UserID(user_id), Value: model.RatingValue(value)})
+		ratings = append(ratings, model.Rating{RecordID: recordID, RecordType: recordType, UserID: model.UserID(user_id), Value: model.RatingValue(value), Timestamp: ratedAt})
 
 	}
 	if len(ratings) == 0 {
@@ -45,8 +57,258 @@ func (r *Repository) Get(ctx context.Context, recordID model.RecordID, recordTyp
 
 }
 
-// Put adds a rating for a given record.
-func (r *Repository) Put(ctx context.Context, recordID model.RecordID, recordType model.RecordType, rating *model.Rating) error {
-	_, err := r.db.ExecContext(ctx, "INSERT INTO ratings(record_id, record_type, user_id, value) VALUES (?, ?, ?, ?)", recordID, recordType, rating.UserID, rating.Value)
+// Put adds or replaces the rating of a user for a given record and updates the record aggregate.
+// A non-empty eventID is recorded as processed in the same transaction, the rating of an already
+// processed event is not written again.
+func (r *Repository) Put(ctx context.Context, recordID model.RecordID, recordType model.RecordType, rating *model.Rating, eventID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if ok, err := recordEvent(ctx, tx, eventID); err != nil || !ok {
+		return err
+	}
+	old, err := lockRating(ctx, tx, recordID, recordType, rating.UserID)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO ratings(record_id, record_type, user_id, value, rated_at) VALUES (?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE value = VALUES(value), rated_at = VALUES(rated_at)", recordID, recordType, rating.UserID, rating.Value, rating.Timestamp); err != nil {
+		return err
+	}
+	if old != nil {
+		if err := updateAggregates(ctx, tx, recordID, recordType, old.Timestamp, -int64(old.Value), -1); err != nil {
+			return err
+		}
+	}
+	if err := updateAggregates(ctx, tx, recordID, recordType, rating.Timestamp, int64(rating.Value), 1); err != nil {
+		return err
+	}
+	e, err := repository.RatingChangedEvent(recordID, recordType, rating, false)
+	if err != nil {
+		return err
+	}
+	if err := outboxmysql.Insert(ctx, tx, outboxTable, e); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Delete removes the rating of a user for a given record and updates the record aggregate.
+// A non-empty eventID is recorded as processed in the same transaction. Deleting a missing
+// rating for an event only records the event, without an event it returns ErrNotFound.
+func (r *Repository) Delete(ctx context.Context, recordID model.RecordID, recordType model.RecordType, userID model.UserID, eventID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if ok, err := recordEvent(ctx, tx, eventID); err != nil || !ok {
+		return err
+	}
+	old, err := lockRating(ctx, tx, recordID, recordType, userID)
+	if err != nil {
+		return err
+	}
+	if old == nil && eventID != "" {
+		return tx.Commit()
+	} else if old == nil {
+		return repository.ErrNotFound
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM ratings WHERE record_id = ? AND record_type = ? AND user_id = ?", recordID, recordType, userID); err != nil {
+		return err
+	}
+	if err := updateAggregates(ctx, tx, recordID, recordType, old.Timestamp, -int64(old.Value), -1); err != nil {
+		return err
+	}
+	e, err := repository.RatingChangedEvent(recordID, recordType, old, true)
+	if err != nil {
+		return err
+	}
+	if err := outboxmysql.Insert(ctx, tx, outboxTable, e); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListByUser returns the ratings given by a user sorted by time.
+func (r *Repository) ListByUser(ctx context.Context, userID model.UserID, opts model.UserRatingsOptions) ([]model.Rating, error) {
+	query := "SELECT record_id, record_type, value, rated_at FROM ratings WHERE user_id = ?"
+	args := []any{userID}
+	if opts.RecordType != "" {
+		query += " AND record_type = ?"
+		args = append(args, opts.RecordType)
+	}
+	cmp, dir := "<", "DESC"
+	if opts.OldestFirst {
+		cmp, dir = ">", "ASC"
+	}
+	if opts.After != nil {
+		query += " AND (rated_at, record_type, record_id) " + cmp + " (?, ?, ?)"
+		args = append(args, opts.After.Timestamp, opts.After.RecordType, opts.After.RecordID)
+	}
+	query += " ORDER BY rated_at " + dir + ", record_type " + dir + ", record_id " + dir
+	if opts.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, opts.Limit)
+	}
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ratings []model.Rating
+	for rows.Next() {
+		rating := model.Rating{UserID: userID}
+		if err := rows.Scan(&rating.RecordID, &rating.RecordType, &rating.Value, &rating.Timestamp); err != nil {
+			return nil, err
+		}
+		ratings = append(ratings, rating)
+	}
+	return ratings, rows.Err()
+}
+
+// GetAggregate returns the running totals of the ratings of a given record.
+func (r *Repository) GetAggregate(ctx context.Context, recordID model.RecordID, recordType model.RecordType) (*model.Aggregate, error) {
+	var agg model.Aggregate
+	row := r.db.QueryRowContext(ctx, "SELECT rating_sum, rating_count FROM rating_aggregates WHERE record_id = ? AND record_type = ? AND rating_count > 0", recordID, recordType)
+	if err := row.Scan(&agg.Sum, &agg.Count); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+	return &agg, nil
+}
+
+// GetAggregates returns the running totals of the ratings of the given records of a type keyed by record id.
+// Records without ratings are absent from the result.
+func (r *Repository) GetAggregates(ctx context.Context, recordIDs []model.RecordID, recordType model.RecordType) (map[model.RecordID]*model.Aggregate, error) {
+	res := make(map[model.RecordID]*model.Aggregate, len(recordIDs))
+	if len(recordIDs) == 0 {
+		return res, nil
+	}
+	args := []any{recordType}
+	for _, id := range recordIDs {
+		args = append(args, id)
+	}
+	rows, err := r.db.QueryContext(ctx, "SELECT record_id, rating_sum, rating_count FROM rating_aggregates WHERE record_type = ? AND rating_count > 0 AND record_id IN ("+strings.TrimSuffix(strings.Repeat("?, ", len(recordIDs)), ", ")+")", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id model.RecordID
+		var agg model.Aggregate
+		if err := rows.Scan(&id, &agg.Sum, &agg.Count); err != nil {
+			return nil, err
+		}
+		res[id] = &agg
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetTopRated returns the records of a type with the highest average rating. Without a window
+// the records are read in the order of the rank index on the stored mean of their aggregates.
+// With a window only ratings given in the last window, rounded up to whole days, are counted.
+func (r *Repository) GetTopRated(ctx context.Context, q model.TopRatedQuery) ([]model.RankedRecord, error) {
+	var rows *sql.Rows
+	var err error
+	if q.Window > 0 {
+		since := time.Now().UTC().Add(-q.Window).Format(model.DayLayout)
+		rows, err = r.db.QueryContext(ctx, "SELECT record_id, SUM(rating_sum), SUM(rating_count) AS votes FROM rating_daily_aggregates WHERE record_type = ? AND day >= ? GROUP BY record_id HAVING votes > 0 AND votes >= ? ORDER BY SUM(rating_sum) / votes DESC, votes DESC, record_id LIMIT ?", q.RecordType, since, q.MinVotes, q.Limit)
+	} else {
+		rows, err = r.db.QueryContext(ctx, "SELECT record_id, rating_sum, rating_count FROM rating_aggregates WHERE record_type = ? AND rating_count > 0 AND rating_count >= ? ORDER BY mean DESC, rating_count DESC, record_id LIMIT ?", q.RecordType, q.MinVotes, q.Limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var res []model.RankedRecord
+	for rows.Next() {
+		var recordID model.RecordID
+		var agg model.Aggregate
+		if err := rows.Scan(&recordID, &agg.Sum, &agg.Count); err != nil {
+			return nil, err
+		}
+		res = append(res, model.RankedRecord{RecordID: recordID, Rating: agg.Mean(), Count: agg.Count})
+	}
+	return res, rows.Err()
+}
+
+// GetStats returns the distribution of the ratings of a given record.
+func (r *Repository) GetStats(ctx context.Context, recordID model.RecordID, recordType model.RecordType) (*model.RatingStats, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT value, COUNT(*) FROM ratings WHERE record_id = ? AND record_type = ? GROUP BY value", recordID, recordType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	histogram := map[model.RatingValue]int64{}
+	for rows.Next() {
+		var value int32
+		var count int64
+		if err := rows.Scan(&value, &count); err != nil {
+			return nil, err
+		}
+		histogram[model.RatingValue(value)] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(histogram) == 0 {
+		return nil, repository.ErrNotFound
+	}
+	return model.NewRatingStats(histogram), nil
+}
+
+// ratedDay is the UTC day of a rating, which incremental writes bucket daily totals by.
+// TIMESTAMP columns are read in the session time zone, which need not be UTC.
+const ratedDay = "DATE(CONVERT_TZ(rated_at, @@session.time_zone, '+00:00'))"
+
+// RebuildAggregates recomputes the running totals of all records from the ratings table.
+func (r *Repository) RebuildAggregates(ctx context.Context) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, "DELETE FROM rating_aggregates"); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO rating_aggregates (record_id, record_type, rating_sum, rating_count) SELECT record_id, record_type, SUM(value), COUNT(*) FROM ratings GROUP BY record_id, record_type"); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM rating_daily_aggregates"); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO rating_daily_aggregates (record_id, record_type, day, rating_sum, rating_count) SELECT record_id, record_type, "+ratedDay+", SUM(value), COUNT(*) FROM ratings GROUP BY record_id, record_type, "+ratedDay); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// lockRating locks the rating row of a user for the rest of the transaction and returns it.
+// It returns nil if the user has not rated the record.
+func lockRating(ctx context.Context, tx *sql.Tx, recordID model.RecordID, recordType model.RecordType, userID model.UserID) (*model.Rating, error) {
+	rating := &model.Rating{RecordID: recordID, RecordType: recordType, UserID: userID}
+	row := tx.QueryRowContext(ctx, "SELECT value, rated_at FROM ratings WHERE record_id = ? AND record_type = ? AND user_id = ? FOR UPDATE", recordID, recordType, userID)
+	if err := row.Scan(&rating.Value, &rating.Timestamp); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return rating, nil
+}
+
+// updateAggregates applies a change to the running totals of a record and of the day of the rating.
+func updateAggregates(ctx context.Context, tx *sql.Tx, recordID model.RecordID, recordType model.RecordType, ratedAt time.Time, sumDelta, countDelta int64) error {
+	if _, err := tx.ExecContext(ctx, "INSERT INTO rating_aggregates (record_id, record_type, rating_sum, rating_count) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE rating_sum = rating_sum + VALUES(rating_sum), rating_count = rating_count + VALUES(rating_count)", recordID, recordType, sumDelta, countDelta); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, "INSERT INTO rating_daily_aggregates (record_id, record_type, day, rating_sum, rating_count) VALUES (?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE rating_sum = rating_sum + VALUES(rating_sum), rating_count = rating_count + VALUES(rating_count)", recordID, recordType, ratedAt.UTC().Format(model.DayLayout), sumDelta, countDelta)
 	return err
 }
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"movieexample.com/pkg/outbox"
+	"movieexample.com/rating/pkg/model"
+)
+
+// RatingChangedEvent returns the outbox event of a put rating or, if deleted is set, of a deleted one.
+func RatingChangedEvent(recordID model.RecordID, recordType model.RecordType, rating *model.Rating, deleted bool) (outbox.Event, error) {
+	payload := model.RatingChanged{
+		RecordID:   recordID,
+		RecordType: recordType,
+		UserID:     rating.UserID,
+		Deleted:    deleted,
+	}
+	if !deleted {
+		payload.Value, payload.Timestamp = rating.Value, rating.Timestamp
+	}
+	return outbox.NewEvent(outbox.TypeRatingChanged, string(recordType)+"/"+string(recordID), payload)
+}
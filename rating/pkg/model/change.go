@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// RatingChanged defines the payload of an event published when a rating is put or deleted.
+type RatingChanged struct {
+	RecordID   RecordID    `json:"record_id"`
+	RecordType RecordType  `json:"record_type"`
+	UserID     UserID      `json:"user_id"`
+	Value      RatingValue `json:"value,omitempty"`
+	Timestamp  time.Time   `json:"timestamp,omitempty"`
+	Deleted    bool        `json:"deleted,omitempty"`
+}
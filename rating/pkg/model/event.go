@@ -0,0 +1,109 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"movieexample.com/gen"
+)
+
+// RatingEventSchemaVersion defines the current version of the protobuf rating event schema.
+const RatingEventSchemaVersion = 1
+
+// HeaderContentType defines the message header naming the encoding of a rating event.
+const HeaderContentType = "content-type"
+
+// Encodings of rating events. Events without a content type are legacy JSON.
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// ErrUnsupportedEncoding is returned when a rating event has an unknown content type or schema version.
+var ErrUnsupportedEncoding = errors.New("unsupported rating event encoding")
+
+// legacyRecordTypes maps the numeric record types of legacy JSON events.
+var legacyRecordTypes = map[int]RecordType{
+	1: RecordTypeMovie,
+	2: RecordTypeEpisode,
+}
+
+// EncodeRatingEvent encodes a rating event with the given content type.
+func EncodeRatingEvent(e *RatingEvent, contentType string) ([]byte, error) {
+	switch contentType {
+	case ContentTypeJSON, "":
+		return json.Marshal(e)
+	case ContentTypeProtobuf:
+		return proto.Marshal(RatingEventToProto(e))
+	default:
+		return nil, fmt.Errorf("%w: content type %q", ErrUnsupportedEncoding, contentType)
+	}
+}
+
+// DecodeRatingEvent decodes a rating event with the given content type.
+func DecodeRatingEvent(data []byte, contentType string) (*RatingEvent, error) {
+	switch contentType {
+	case ContentTypeJSON, "":
+		return decodeLegacyRatingEvent(data)
+	case ContentTypeProtobuf:
+		var e gen.RatingEvent
+		if err := proto.Unmarshal(data, &e); err != nil {
+			return nil, err
+		}
+		if e.SchemaVersion < 1 || e.SchemaVersion > RatingEventSchemaVersion {
+			return nil, fmt.Errorf("%w: schema version %d", ErrUnsupportedEncoding, e.SchemaVersion)
+		}
+		return RatingEventFromProto(&e), nil
+	default:
+		return nil, fmt.Errorf("%w: content type %q", ErrUnsupportedEncoding, contentType)
+	}
+}
+
+// decodeLegacyRatingEvent decodes a JSON rating event. Keys are accepted in snake_case and in
+// camelCase and the record type may be given as a legacy number.
+func decodeLegacyRatingEvent(data []byte) (*RatingEvent, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	fields := make(map[string]json.RawMessage, len(raw))
+	for k, v := range raw {
+		fields[strings.ToLower(strings.ReplaceAll(k, "_", ""))] = v
+	}
+	var e RatingEvent
+	var recordType json.RawMessage
+	for key, dst := range map[string]any{
+		"id":         &e.ID,
+		"userid":     &e.UserID,
+		"recordid":   &e.RecordID,
+		"recordtype": &recordType,
+		"value":      &e.Value,
+		"eventtype":  &e.EventType,
+		"timestamp":  &e.Timestamp,
+		"providerid": &e.ProviderID,
+	} {
+		v, ok := fields[key]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(v, dst); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	if len(recordType) > 0 {
+		if n, err := strconv.Atoi(string(recordType)); err == nil {
+			t, ok := legacyRecordTypes[n]
+			if !ok {
+				return nil, fmt.Errorf("%w: %d", ErrUnknownRecordType, n)
+			}
+			e.RecordType = t
+		} else if err := json.Unmarshal(recordType, &e.RecordType); err != nil {
+			return nil, fmt.Errorf("recordtype: %w", err)
+		}
+	}
+	return &e, nil
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"movieexample.com/gen"
+)
+
+func TestDecodeLegacyRatingEvent(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want *RatingEvent
+	}{
+		{
+			name: "snake case",
+			data: `{"user_id":"105","record_id":"1","record_type":"movie","value":4,"event_type":"put"}`,
+			want: &RatingEvent{UserID: "105", RecordID: "1", RecordType: RecordTypeMovie, Value: 4, EventType: RatingEventTypePut},
+		},
+		{
+			name: "camel case with numeric record type",
+			data: `{"userId":"105","recordId":"1","recordType":1,"value":1,"providerId":"test-provider","eventType":"put"}`,
+			want: &RatingEvent{UserID: "105", RecordID: "1", RecordType: RecordTypeMovie, Value: 1, EventType: RatingEventTypePut, ProviderID: "test-provider"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeRatingEvent([]byte(tt.data), ContentTypeJSON)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := DecodeRatingEvent([]byte(`{"recordType":42}`), "")
+	assert.ErrorIs(t, err, ErrUnknownRecordType)
+}
+
+func TestRatingEventProtobuf(t *testing.T) {
+	e := &RatingEvent{ID: "e1", UserID: "105", RecordID: "1", RecordType: RecordTypeMovie, Value: 5, EventType: RatingEventTypePut, Timestamp: time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC), ProviderID: "p"}
+	data, err := EncodeRatingEvent(e, ContentTypeProtobuf)
+	require.NoError(t, err)
+	got, err := DecodeRatingEvent(data, ContentTypeProtobuf)
+	assert.NoError(t, err)
+	assert.Equal(t, e, got)
+
+	data, err = proto.Marshal(&gen.RatingEvent{SchemaVersion: RatingEventSchemaVersion + 1})
+	require.NoError(t, err)
+	_, err = DecodeRatingEvent(data, ContentTypeProtobuf)
+	assert.ErrorIs(t, err, ErrUnsupportedEncoding)
+
+	_, err = DecodeRatingEvent(data, "text/csv")
+	assert.ErrorIs(t, err, ErrUnsupportedEncoding)
+}
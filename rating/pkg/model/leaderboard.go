@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// DayLayout is the time layout of the day buckets of windowed rating aggregates.
+const DayLayout = "2006-01-02"
+
+// TopRatedQuery defines a request of the best rated records of a type.
+type TopRatedQuery struct {
+	RecordType RecordType
+	Limit      int
+	// MinVotes excludes records with fewer ratings.
+	MinVotes int64
+	// Window limits the ranking to ratings given in the last window if set.
+	Window time.Duration
+}
+
+// RankedRecord defines a record in a rating leaderboard.
+type RankedRecord struct {
+	RecordID RecordID `json:"record_id"`
+	Rating   float64  `json:"rating"`
+	Count    int64    `json:"count"`
+}
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// RatingCursor defines a position in the time-sorted rating history of a user.
+type RatingCursor struct {
+	Timestamp  time.Time  `json:"t"`
+	RecordType RecordType `json:"rt"`
+	RecordID   RecordID   `json:"r"`
+}
+
+// CursorOf returns the cursor pointing right after the given rating.
+func CursorOf(r *Rating) *RatingCursor {
+	return &RatingCursor{Timestamp: r.Timestamp, RecordType: r.RecordType, RecordID: r.RecordID}
+}
+
+// Before reports whether c comes before o in chronological order.
+func (c *RatingCursor) Before(o *RatingCursor) bool {
+	if !c.Timestamp.Equal(o.Timestamp) {
+		return c.Timestamp.Before(o.Timestamp)
+	}
+	if c.RecordType != o.RecordType {
+		return c.RecordType < o.RecordType
+	}
+	return c.RecordID < o.RecordID
+}
+
+// UserRatingsOptions defines the parameters of a user rating history listing.
+type UserRatingsOptions struct {
+	// RecordType limits the listing to records of a type if set.
+	RecordType RecordType
+	Limit      int
+	After      *RatingCursor
+	// OldestFirst sorts the listing in chronological order instead of newest first.
+	OldestFirst bool
+}
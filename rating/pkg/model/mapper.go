@@ -0,0 +1,71 @@
+package model
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"movieexample.com/gen"
+)
+
+// RatingStatsToProto converts a RatingStats struct into a generated proto counterpart.
+func RatingStatsToProto(s *RatingStats) *gen.RatingStats {
+	res := &gen.RatingStats{
+		Count:     s.Count,
+		Mean:      s.Mean,
+		Median:    s.Median,
+		StdDev:    s.StdDev,
+		Histogram: make(map[int32]int64, len(s.Histogram)),
+	}
+	for v, n := range s.Histogram {
+		res.Histogram[int32(v)] = n
+	}
+	return res
+}
+
+// RatingStatsFromProto converts a generated proto counterpart into a RatingStats struct.
+func RatingStatsFromProto(s *gen.RatingStats) *RatingStats {
+	res := &RatingStats{
+		Count:     s.Count,
+		Mean:      s.Mean,
+		Median:    s.Median,
+		StdDev:    s.StdDev,
+		Histogram: make(map[RatingValue]int64, len(s.Histogram)),
+	}
+	for v, n := range s.Histogram {
+		res.Histogram[RatingValue(v)] = n
+	}
+	return res
+}
+
+// RatingEventToProto converts a RatingEvent struct into a generated proto counterpart of the current schema version.
+func RatingEventToProto(e *RatingEvent) *gen.RatingEvent {
+	res := &gen.RatingEvent{
+		SchemaVersion: RatingEventSchemaVersion,
+		Id:            e.ID,
+		UserId:        string(e.UserID),
+		RecordId:      string(e.RecordID),
+		RecordType:    string(e.RecordType),
+		Value:         int32(e.Value),
+		EventType:     string(e.EventType),
+		ProviderId:    e.ProviderID,
+	}
+	if !e.Timestamp.IsZero() {
+		res.Timestamp = timestamppb.New(e.Timestamp)
+	}
+	return res
+}
+
+// RatingEventFromProto converts a generated proto counterpart into a RatingEvent struct.
+func RatingEventFromProto(e *gen.RatingEvent) *RatingEvent {
+	res := &RatingEvent{
+		ID:         e.Id,
+		UserID:     UserID(e.UserId),
+		RecordID:   RecordID(e.RecordId),
+		RecordType: RecordType(e.RecordType),
+		Value:      RatingValue(e.Value),
+		EventType:  RatingEventType(e.EventType),
+		ProviderID: e.ProviderId,
+	}
+	if e.Timestamp != nil {
+		res.Timestamp = e.Timestamp.AsTime()
+	}
+	return res
+}
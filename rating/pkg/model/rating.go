@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 // RecordID defines a record id. Together with RecordType identifies unique records across all types.
 type RecordID string
 
@@ -8,7 +10,8 @@ type RecordType string
 
 // Existing record types.
 const (
-	RecordTypeMovie = RecordType("movie")
+	RecordTypeMovie   = RecordType("movie")
+	RecordTypeEpisode = RecordType("episode")
 )
 
 // UserID defines a user id.
@@ -19,25 +22,47 @@ type RatingValue int
 
 // Rating defines an individual rating created by a user some record.
 type Rating struct {
-	RecordID   string      `json:"record_id"`
-	RecordType string      `json:"record_type"`
+	RecordID   RecordID    `json:"record_id"`
+	RecordType RecordType  `json:"record_type"`
 	UserID     UserID      `json:"user_id"`
 	Value      RatingValue `json:"value"`
+	Timestamp  time.Time   `json:"timestamp"`
+}
+
+// Aggregate defines the running totals of all ratings of a record.
+type Aggregate struct {
+	Sum   int64 `json:"sum"`
+	Count int64 `json:"count"`
+}
+
+// Mean returns the average rating value of the aggregate.
+func (a Aggregate) Mean() float64 {
+	if a.Count == 0 {
+		return 0
+	}
+	return float64(a.Sum) / float64(a.Count)
 }
 
 // RatingEventType defines the type of a rating event.
 type RatingEventType string
 
 const (
-	RatingEventTypePut = "put"
-	RatingEventTypeDelete = "delete"
+	RatingEventTypePut    = RatingEventType("put")
+	RatingEventTypeDelete = RatingEventType("delete")
 )
 
 // RatingEvent defines an event containing rating information.
 type RatingEvent struct {
-	UserID     UserID      `json:"user_id"`
-	RecordID  RecordID `json:"record_id"`
-	RecordType RecordType `json:"record_type"`
-	Value      RatingValue `json:"value"`
-	EventType RatingEventType `json:"event_type"`
-}
\ No newline at end of file
+	// ID identifies the event so that redelivered events are only applied once. Events without an id are
+	// applied on every delivery.
+	ID         string          `json:"id,omitempty"`
+	UserID     UserID          `json:"user_id"`
+	RecordID   RecordID        `json:"record_id"`
+	RecordType RecordType      `json:"record_type"`
+	Value      RatingValue     `json:"value"`
+	EventType  RatingEventType `json:"event_type"`
+	// Timestamp is the time the rating was given, the ingestion time is used if it is zero.
+	Timestamp time.Time `json:"timestamp"`
+	// ProviderID identifies the system that published the event.
+	ProviderID string `json:"provider_id,omitempty"`
+}
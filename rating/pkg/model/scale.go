@@ -0,0 +1,53 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidRating is returned when a rating value is not allowed by the scale of its record type.
+var ErrInvalidRating = errors.New("rating value out of scale")
+
+// ErrUnknownRecordType is returned when there is no rating scale for a record type.
+var ErrUnknownRecordType = errors.New("unknown record type")
+
+// Scale defines the allowed rating values of a record type:
+// the values from Min to Max in increments of Step.
+type Scale struct {
+	Min  RatingValue `yaml:"min" json:"min"`
+	Max  RatingValue `yaml:"max" json:"max"`
+	Step RatingValue `yaml:"step" json:"step"`
+}
+
+// Validate returns ErrInvalidRating if the value is not allowed by the scale.
+func (s Scale) Validate(v RatingValue) error {
+	step := s.Step
+	if step <= 0 {
+		step = 1
+	}
+	if v < s.Min || v > s.Max || (v-s.Min)%step != 0 {
+		return fmt.Errorf("%w: %d is not in %d..%d by %d", ErrInvalidRating, v, s.Min, s.Max, step)
+	}
+	return nil
+}
+
+// ScaleRegistry defines the rating scales per record type.
+type ScaleRegistry map[RecordType]Scale
+
+// DefaultScales returns the rating scales of the existing record types.
+func DefaultScales() ScaleRegistry {
+	return ScaleRegistry{
+		RecordTypeMovie:   {Min: 1, Max: 5, Step: 1},
+		RecordTypeEpisode: {Min: 1, Max: 10, Step: 1},
+	}
+}
+
+// Validate returns ErrUnknownRecordType or ErrInvalidRating if the value is not
+// allowed for the record type.
+func (r ScaleRegistry) Validate(recordType RecordType, v RatingValue) error {
+	s, ok := r[recordType]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownRecordType, recordType)
+	}
+	return s.Validate(v)
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScaleRegistryValidate(t *testing.T) {
+	scales := ScaleRegistry{
+		RecordTypeMovie:   {Min: 1, Max: 5, Step: 1},
+		RecordTypeEpisode: {Min: 2, Max: 10, Step: 2},
+	}
+	tests := []struct {
+		name       string
+		recordType RecordType
+		value      RatingValue
+		wantErr    error
+	}{
+		{name: "min", recordType: RecordTypeMovie, value: 1},
+		{name: "max", recordType: RecordTypeMovie, value: 5},
+		{name: "below min", recordType: RecordTypeMovie, value: 0, wantErr: ErrInvalidRating},
+		{name: "above max", recordType: RecordTypeMovie, value: 6, wantErr: ErrInvalidRating},
+		{name: "on step", recordType: RecordTypeEpisode, value: 8},
+		{name: "off step", recordType: RecordTypeEpisode, value: 7, wantErr: ErrInvalidRating},
+		{name: "unknown record type", recordType: RecordType("book"), value: 3, wantErr: ErrUnknownRecordType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, scales.Validate(tt.recordType, tt.value), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"math"
+	"sort"
+)
+
+// RatingStats defines the distribution of the ratings of a record.
+type RatingStats struct {
+	Count     int64                 `json:"count"`
+	Mean      float64               `json:"mean"`
+	Median    float64               `json:"median"`
+	StdDev    float64               `json:"std_dev"`
+	Histogram map[RatingValue]int64 `json:"histogram"`
+}
+
+// NewRatingStats computes rating statistics from the number of ratings per rating value.
+func NewRatingStats(histogram map[RatingValue]int64) *RatingStats {
+	s := &RatingStats{Histogram: histogram}
+	values := make([]RatingValue, 0, len(histogram))
+	var sum float64
+	for v, n := range histogram {
+		values = append(values, v)
+		s.Count += n
+		sum += float64(v) * float64(n)
+	}
+	if s.Count == 0 {
+		return s
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	s.Mean = sum / float64(s.Count)
+	s.Median = (float64(nth(values, histogram, (s.Count-1)/2)) + float64(nth(values, histogram, s.Count/2))) / 2
+	var sq float64
+	for v, n := range histogram {
+		d := float64(v) - s.Mean
+		sq += d * d * float64(n)
+	}
+	s.StdDev = math.Sqrt(sq / float64(s.Count))
+	return s
+}
+
+// nth returns the rating value at the zero-based position i of all ratings in sorted order.
+func nth(sorted []RatingValue, histogram map[RatingValue]int64, i int64) RatingValue {
+	for _, v := range sorted {
+		if i < histogram[v] {
+			return v
+		}
+		i -= histogram[v]
+	}
+	return sorted[len(sorted)-1]
+}
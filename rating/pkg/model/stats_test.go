@@ -0,0 +1,36 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRatingStats(t *testing.T) {
+	tests := []struct {
+		name      string
+		histogram map[RatingValue]int64
+		want      *RatingStats
+	}{
+		{
+			name:      "empty",
+			histogram: map[RatingValue]int64{},
+			want:      &RatingStats{Histogram: map[RatingValue]int64{}},
+		},
+		{
+			name:      "odd count",
+			histogram: map[RatingValue]int64{1: 1, 3: 1, 5: 1},
+			want:      &RatingStats{Count: 3, Mean: 3, Median: 3, StdDev: 1.632993161855452, Histogram: map[RatingValue]int64{1: 1, 3: 1, 5: 1}},
+		},
+		{
+			name:      "even count",
+			histogram: map[RatingValue]int64{2: 2, 4: 2},
+			want:      &RatingStats{Count: 4, Mean: 3, Median: 3, StdDev: 1, Histogram: map[RatingValue]int64{2: 2, 4: 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewRatingStats(tt.histogram))
+		})
+	}
+}
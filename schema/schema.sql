@@ -1,13 +1,113 @@
 CREATE TABLE IF NOT EXISTS movies (
-    id VARCHAR(255),
+    id VARCHAR(255) PRIMARY KEY,
     title VARCHAR(255),
     description TEXT,
-    director VARCHAR(255)
+    director VARCHAR(255),
+    release_date DATE NULL,
+    runtime_minutes INT NOT NULL DEFAULT 0,
+    version BIGINT NOT NULL DEFAULT 0,
+    INDEX (director),
+    FULLTEXT INDEX movies_text (title, description, director)
+);
+
+CREATE TABLE IF NOT EXISTS movie_genres (
+    movie_id VARCHAR(255),
+    position INT NOT NULL,
+    genre VARCHAR(255) NOT NULL,
+    PRIMARY KEY (movie_id, position),
+    INDEX (genre),
+    FOREIGN KEY (movie_id) REFERENCES movies (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS movie_cast (
+    movie_id VARCHAR(255),
+    position INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    character_name VARCHAR(255),
+    PRIMARY KEY (movie_id, position),
+    FOREIGN KEY (movie_id) REFERENCES movies (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS movie_crew (
+    movie_id VARCHAR(255),
+    position INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    job VARCHAR(255),
+    PRIMARY KEY (movie_id, position),
+    FOREIGN KEY (movie_id) REFERENCES movies (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS movie_languages (
+    movie_id VARCHAR(255),
+    position INT NOT NULL,
+    language VARCHAR(35) NOT NULL,
+    PRIMARY KEY (movie_id, position),
+    FOREIGN KEY (movie_id) REFERENCES movies (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS movie_external_ids (
+    movie_id VARCHAR(255),
+    source VARCHAR(64) NOT NULL,
+    external_id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (movie_id, source),
+    FOREIGN KEY (movie_id) REFERENCES movies (id) ON DELETE CASCADE
 );
 
 CREATE TABLE IF NOT EXISTS ratings (
     record_id VARCHAR(255),
     record_type VARCHAR(255),
     user_id VARCHAR(255),
-    value INT
+    value INT,
+    rated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (record_id, record_type, user_id),
+    INDEX ratings_user_time (user_id, rated_at, record_type, record_id),
+    INDEX ratings_user_type_time (user_id, record_type, rated_at, record_id)
+);
+
+CREATE TABLE IF NOT EXISTS rating_aggregates (
+    record_id VARCHAR(255),
+    record_type VARCHAR(255),
+    rating_sum BIGINT NOT NULL,
+    rating_count BIGINT NOT NULL,
+    mean DOUBLE AS (IF(rating_count > 0, CAST(rating_sum AS DOUBLE) / rating_count, 0)) STORED,
+    PRIMARY KEY (record_id, record_type),
+    INDEX rating_aggregates_rank (record_type, mean DESC, rating_count DESC)
+);
+
+CREATE TABLE IF NOT EXISTS rating_daily_aggregates (
+    record_id VARCHAR(255),
+    record_type VARCHAR(255),
+    day DATE,
+    rating_sum BIGINT NOT NULL,
+    rating_count BIGINT NOT NULL,
+    PRIMARY KEY (record_id, record_type, day),
+    INDEX rating_daily_aggregates_type_day (record_type, day)
+);
+
+CREATE TABLE IF NOT EXISTS rating_processed_events (
+    event_id VARCHAR(255),
+    processed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (event_id)
+);
+
+CREATE TABLE IF NOT EXISTS metadata_outbox (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    event_type VARCHAR(64) NOT NULL,
+    aggregate_id VARCHAR(255) NOT NULL,
+    payload BLOB NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    published_at TIMESTAMP(6) NULL,
+    PRIMARY KEY (id),
+    INDEX metadata_outbox_pending (published_at, id)
+);
+
+CREATE TABLE IF NOT EXISTS rating_outbox (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    event_type VARCHAR(64) NOT NULL,
+    aggregate_id VARCHAR(255) NOT NULL,
+    payload BLOB NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    published_at TIMESTAMP(6) NULL,
+    PRIMARY KEY (id),
+    INDEX rating_outbox_pending (published_at, id)
 );
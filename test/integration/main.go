@@ -48,11 +48,15 @@ func main() {
 		Title:       "The Movie",
 		Description: "The Movie, the one and only",
 		Director:    "Mr D.",
+		Genres:      []string{"Drama"},
+		ReleaseDate: "2001-02-03",
 	}
 
-	if _, err := metadataClient.PutMetadata(ctx, &gen.PutMetadataRequest{Metadata: m}); err != nil {
+	putMetadataResp, err := metadataClient.PutMetadata(ctx, &gen.PutMetadataRequest{Metadata: m})
+	if err != nil {
 		log.Fatalf("put metadata: %v", err)
 	}
+	m.Version = putMetadataResp.Version
 
 	log.Println("Retrieving test metadata via metadata service")
 